@@ -0,0 +1,194 @@
+package toki
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// zoneCandidate is a zone in which an abbreviation is in use, together
+// with the ISO 3166 territory it is used in.
+type zoneCandidate struct {
+	territory string
+	zone      string
+}
+
+// zoneAbbreviations lists the zones known to use each abbreviation.
+// Zones sharing an abbreviation and offset only need one entry.
+var zoneAbbreviations = map[string][]zoneCandidate{
+	"ACDT": {{"AU", "Australia/Adelaide"}},
+	"ACST": {{"AU", "Australia/Adelaide"}},
+	"ADT":  {{"CA", "America/Halifax"}},
+	"AEDT": {{"AU", "Australia/Sydney"}},
+	"AEST": {{"AU", "Australia/Sydney"}},
+	"AKDT": {{"US", "America/Anchorage"}},
+	"AKST": {{"US", "America/Anchorage"}},
+	"AST":  {{"CA", "America/Halifax"}, {"PR", "America/Puerto_Rico"}},
+	"AWST": {{"AU", "Australia/Perth"}},
+	"BST":  {{"GB", "Europe/London"}},
+	"CAT":  {{"MZ", "Africa/Maputo"}},
+	"CDT":  {{"US", "America/Chicago"}, {"CU", "America/Havana"}},
+	"CEST": {{"DE", "Europe/Berlin"}},
+	"CET":  {{"DE", "Europe/Berlin"}},
+	"ChST": {{"GU", "Pacific/Guam"}},
+	"CST":  {{"US", "America/Chicago"}, {"CN", "Asia/Shanghai"}, {"TW", "Asia/Taipei"}, {"CU", "America/Havana"}},
+	"EAT":  {{"KE", "Africa/Nairobi"}},
+	"EDT":  {{"US", "America/New_York"}},
+	"EEST": {{"FI", "Europe/Helsinki"}},
+	"EET":  {{"FI", "Europe/Helsinki"}},
+	"EST":  {{"US", "America/New_York"}},
+	"HKT":  {{"HK", "Asia/Hong_Kong"}},
+	"HST":  {{"US", "Pacific/Honolulu"}},
+	"IDT":  {{"IL", "Asia/Jerusalem"}},
+	"IST":  {{"IN", "Asia/Kolkata"}, {"IE", "Europe/Dublin"}, {"IL", "Asia/Jerusalem"}},
+	"JST":  {{"JP", "Asia/Tokyo"}},
+	"KST":  {{"KR", "Asia/Seoul"}},
+	"MDT":  {{"US", "America/Denver"}},
+	"MSK":  {{"RU", "Europe/Moscow"}},
+	"MST":  {{"US", "America/Denver"}},
+	"NDT":  {{"CA", "America/St_Johns"}},
+	"NST":  {{"CA", "America/St_Johns"}},
+	"NZDT": {{"NZ", "Pacific/Auckland"}},
+	"NZST": {{"NZ", "Pacific/Auckland"}},
+	"PDT":  {{"US", "America/Los_Angeles"}},
+	"PKT":  {{"PK", "Asia/Karachi"}},
+	"PST":  {{"US", "America/Los_Angeles"}},
+	"SAST": {{"ZA", "Africa/Johannesburg"}},
+	"WAT":  {{"NG", "Africa/Lagos"}},
+	"WEST": {{"PT", "Europe/Lisbon"}},
+	"WET":  {{"PT", "Europe/Lisbon"}},
+	"WIB":  {{"ID", "Asia/Jakarta"}},
+}
+
+// An AmbiguousZoneError reports a time zone abbreviation that stands for
+// more than one offset at the parsed time.
+type AmbiguousZoneError struct {
+	Abbr       string
+	Candidates []string
+}
+
+func (e *AmbiguousZoneError) Error() string {
+	return fmt.Sprintf("toki: ambiguous time zone abbreviation %q (%s)", e.Abbr, strings.Join(e.Candidates, ", "))
+}
+
+// A ZoneResolver parses times carrying a time zone abbreviation such as
+// "CST", resolving the abbreviation to a Location instead of fabricating
+// a zone with a zero offset as Parse does.
+type ZoneResolver struct {
+	// Abbreviations maps an abbreviation to the Location it stands for.
+	// It takes precedence over Regions.
+	Abbreviations map[string]*Location
+
+	// Regions lists preferred regions in order. An entry is either an
+	// ISO 3166 territory code such as "US" or an IANA zone name prefix
+	// such as "Asia" or "Asia/Kolkata". The first entry matching a known
+	// zone for the abbreviation wins. If Regions is empty, every known
+	// zone is a candidate.
+	Regions []string
+}
+
+// Parse parses value like Parse, resolving the time zone abbreviation in
+// value with r. It returns an *AmbiguousZoneError if the abbreviation
+// maps to different instants in the candidate zones.
+func (r ZoneResolver) Parse(layout, value string, layouts ...string) (Toki, error) {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return Toki{layout: setLayout(layouts...), Time: t}, err
+	}
+
+	abbr, _ := t.Zone()
+	if !hasZoneAbbr(layout) || abbr == "UTC" || strings.HasPrefix(abbr, "GMT") {
+		return Toki{layout: setLayout(layouts...), Time: t}, nil
+	}
+
+	locs, explicit, err := r.candidates(abbr)
+	if err != nil {
+		return Toki{layout: setLayout(layouts...), Time: t}, err
+	}
+
+	var (
+		found time.Time
+		names []string
+		seen  bool
+		ambig bool
+	)
+	for _, loc := range locs {
+		u, err := time.ParseInLocation(layout, value, loc)
+		if err != nil {
+			continue
+		}
+		if u.Location() != loc {
+			if !explicit {
+				// The zone does not use abbr at that time.
+				continue
+			}
+			// An explicit mapping wins even if loc does not know abbr;
+			// read the wall clock in loc.
+			u = time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), u.Nanosecond(), loc)
+		}
+		names = append(names, loc.String())
+		if seen && !u.Equal(found) {
+			ambig = true
+		}
+		if !seen {
+			found, seen = u, true
+		}
+	}
+
+	switch {
+	case !seen:
+		return Toki{layout: setLayout(layouts...), Time: t}, fmt.Errorf("toki: unknown time zone abbreviation %q", abbr)
+	case ambig:
+		return Toki{layout: setLayout(layouts...), Time: t}, &AmbiguousZoneError{Abbr: abbr, Candidates: names}
+	}
+	return Toki{layout: setLayout(layouts...), Time: found}, nil
+}
+
+func (r ZoneResolver) candidates(abbr string) (locs []*Location, explicit bool, err error) {
+	if loc, ok := r.Abbreviations[abbr]; ok {
+		return []*Location{loc}, true, nil
+	}
+
+	known := zoneAbbreviations[abbr]
+	matched := known
+	if len(r.Regions) > 0 {
+		matched = nil
+		for _, region := range r.Regions {
+			for _, c := range known {
+				if c.matches(region) {
+					matched = append(matched, c)
+				}
+			}
+			if len(matched) > 0 {
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return nil, false, fmt.Errorf("toki: unknown time zone abbreviation %q", abbr)
+	}
+
+	locs = make([]*Location, 0, len(matched))
+	for _, c := range matched {
+		loc, err := time.LoadLocation(c.zone)
+		if err != nil {
+			return nil, false, err
+		}
+		locs = append(locs, loc)
+	}
+	return locs, false, nil
+}
+
+func (c zoneCandidate) matches(region string) bool {
+	if len(region) == 2 {
+		return strings.EqualFold(region, c.territory)
+	}
+	return c.zone == region || strings.HasPrefix(c.zone, strings.TrimSuffix(region, "/")+"/")
+}
+
+// hasZoneAbbr reports whether layout contains a time zone abbreviation
+// and no numeric offset, which would take precedence over the abbreviation.
+func hasZoneAbbr(layout string) bool {
+	return strings.Contains(layout, "MST") &&
+		!strings.Contains(layout, "-07") && !strings.Contains(layout, "Z07")
+}
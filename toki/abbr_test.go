@@ -0,0 +1,89 @@
+package toki
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestZoneResolverParse(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const layout = time.RFC1123
+
+	tests := [...]struct {
+		resolver ZoneResolver
+		value    string
+		want     Toki
+	}{
+		0: {ZoneResolver{Regions: []string{"US"}}, "Mon, 02 Jan 2006 15:04:05 CST", Date(2006, January, 2, 21, 4, 5, 0, UTC)},
+		1: {ZoneResolver{Regions: []string{"CN"}}, "Mon, 02 Jan 2006 15:04:05 CST", Date(2006, January, 2, 7, 4, 5, 0, UTC)},
+		2: {ZoneResolver{Regions: []string{"Asia/Shanghai"}}, "Mon, 02 Jan 2006 15:04:05 CST", Date(2006, January, 2, 7, 4, 5, 0, UTC)},
+		// The first region with a known zone wins.
+		3: {ZoneResolver{Regions: []string{"JP", "IN", "US"}}, "Mon, 16 Oct 2023 10:00:00 IST", Date(2023, October, 16, 4, 30, 0, 0, UTC)},
+		4: {ZoneResolver{Regions: []string{"Europe"}}, "Mon, 16 Oct 2023 10:00:00 IST", Date(2023, October, 16, 9, 0, 0, 0, UTC)},
+		// Unambiguous abbreviations need no configuration.
+		5: {ZoneResolver{}, "Mon, 16 Oct 2023 10:00:00 JST", Date(2023, October, 16, 1, 0, 0, 0, UTC)},
+		6: {ZoneResolver{}, "Mon, 16 Oct 2023 10:00:00 PDT", Date(2023, October, 16, 17, 0, 0, 0, UTC)},
+		7: {ZoneResolver{}, "Mon, 16 Oct 2023 10:00:00 UTC", Date(2023, October, 16, 10, 0, 0, 0, UTC)},
+		// Explicit mappings take precedence over regions.
+		8: {ZoneResolver{Abbreviations: map[string]*Location{"CST": tokyo}, Regions: []string{"US"}}, "Mon, 02 Jan 2006 15:04:05 CST", Date(2006, January, 2, 6, 4, 5, 0, UTC)},
+	}
+
+	for i, tt := range tests {
+		got, err := tt.resolver.Parse(layout, tt.value)
+		if err != nil {
+			t.Errorf("#%d:: Parse(%q) error = %v", i, tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("#%d:: Parse(%q) = %v, want %v", i, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestZoneResolverParseErrors(t *testing.T) {
+	const layout = time.RFC1123
+
+	_, err := ZoneResolver{}.Parse(layout, "Mon, 02 Jan 2006 15:04:05 CST")
+	var ambig *AmbiguousZoneError
+	if !errors.As(err, &ambig) {
+		t.Fatalf("Parse of ambiguous CST error = %v, want *AmbiguousZoneError", err)
+	}
+	if ambig.Abbr != "CST" || len(ambig.Candidates) < 2 {
+		t.Errorf("AmbiguousZoneError = %+v", ambig)
+	}
+
+	if _, err := (ZoneResolver{}).Parse(layout, "Mon, 02 Jan 2006 15:04:05 XYZ"); err == nil {
+		t.Errorf("Parse of unknown abbreviation error = nil, want error")
+	}
+	if _, err := (ZoneResolver{Regions: []string{"JP"}}).Parse(layout, "Mon, 02 Jan 2006 15:04:05 CST"); err == nil {
+		t.Errorf("Parse of abbreviation unknown in region error = nil, want error")
+	}
+}
+
+func TestZoneResolverOffsets(t *testing.T) {
+	tests := [...]struct {
+		layout, value string
+		want          Toki
+	}{
+		// A numeric offset wins over any abbreviation.
+		0: {time.RFC1123Z, "Mon, 02 Jan 2006 15:04:05 -0700", Date(2006, January, 2, 22, 4, 5, 0, UTC)},
+		// An abbreviation out of season keeps the offset it stands for.
+		1: {time.RFC1123, "Mon, 02 Jan 2006 15:04:05 PDT", Date(2006, January, 2, 22, 4, 5, 0, UTC)},
+	}
+
+	for i, tt := range tests {
+		got, err := ZoneResolver{}.Parse(tt.layout, tt.value)
+		if err != nil {
+			t.Errorf("#%d:: Parse(%q) error = %v", i, tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("#%d:: Parse(%q) = %v, want %v", i, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package toki
+
+import "time"
+
+// A MonthOption modifies the behavior of AddMonthsClamped and
+// AddYearsClamped.
+type MonthOption int
+
+const (
+	// SnapToMonthEnd keeps a value on the last day of its month on the
+	// last day of the resulting month, so that April 30 plus one month is
+	// May 31 rather than May 30.
+	SnapToMonthEnd MonthOption = iota + 1
+)
+
+// AddMonthsClamped returns t shifted by n months, keeping the wall clock.
+// Unlike AddDate, which normalizes January 31 plus one month to March 3
+// (or 2 in leap years), the day is clamped to the last day of the
+// resulting month, yielding February 28 (or 29). The clamp does not carry
+// over, so January 31 plus two months is March 31 whichever path is taken.
+func (t Toki) AddMonthsClamped(n int, opts ...MonthOption) Toki {
+	year, month, day := t.Date()
+	ym := YearMonth{Year: year, Month: month}.AddMonths(n)
+
+	last := ym.Days()
+	if day > last || (hasMonthOption(opts, SnapToMonthEnd) && day == DaysIn(month, year)) {
+		day = last
+	}
+	hour, min, sec := t.Clock()
+	t.Time = time.Date(ym.Year, ym.Month, day, hour, min, sec, t.Nanosecond(), t.Location())
+	return t
+}
+
+// AddYearsClamped returns t shifted by n years, clamping February 29 to
+// February 28 in common years where AddDate yields March 1. See
+// AddMonthsClamped.
+func (t Toki) AddYearsClamped(n int, opts ...MonthOption) Toki {
+	return t.AddMonthsClamped(n*12, opts...)
+}
+
+func hasMonthOption(opts []MonthOption, o MonthOption) bool {
+	for _, v := range opts {
+		if v == o {
+			return true
+		}
+	}
+	return false
+}
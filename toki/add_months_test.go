@@ -0,0 +1,107 @@
+package toki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddMonthsClamped(t *testing.T) {
+	tests := [...]struct {
+		t    Toki
+		n    int
+		opts []MonthOption
+		want Toki
+	}{
+		0:  {Date(2023, January, 31, 9, 0, 0, 0, UTC), 1, nil, Date(2023, February, 28, 9, 0, 0, 0, UTC)},
+		1:  {Date(2024, January, 31, 9, 0, 0, 0, UTC), 1, nil, Date(2024, February, 29, 9, 0, 0, 0, UTC)},
+		2:  {Date(2023, January, 31, 9, 0, 0, 0, UTC), 2, nil, Date(2023, March, 31, 9, 0, 0, 0, UTC)},
+		3:  {Date(2023, March, 31, 9, 0, 0, 0, UTC), -1, nil, Date(2023, February, 28, 9, 0, 0, 0, UTC)},
+		4:  {Date(2023, December, 31, 0, 0, 0, 0, UTC), 2, nil, Date(2024, February, 29, 0, 0, 0, 0, UTC)},
+		5:  {Date(2023, April, 30, 0, 0, 0, 0, UTC), 1, nil, Date(2023, May, 30, 0, 0, 0, 0, UTC)},
+		6:  {Date(2023, April, 30, 0, 0, 0, 0, UTC), 1, []MonthOption{SnapToMonthEnd}, Date(2023, May, 31, 0, 0, 0, 0, UTC)},
+		7:  {Date(2023, February, 28, 0, 0, 0, 0, UTC), 1, []MonthOption{SnapToMonthEnd}, Date(2023, March, 31, 0, 0, 0, 0, UTC)},
+		8:  {Date(2024, February, 28, 0, 0, 0, 0, UTC), 1, []MonthOption{SnapToMonthEnd}, Date(2024, March, 28, 0, 0, 0, 0, UTC)},
+		9:  {Date(2023, October, 16, 0, 0, 0, 0, UTC), 0, nil, Date(2023, October, 16, 0, 0, 0, 0, UTC)},
+		10: {Date(2023, October, 16, 0, 0, 0, 0, UTC), -34, nil, Date(2020, December, 16, 0, 0, 0, 0, UTC)},
+	}
+
+	for i, tt := range tests {
+		tt.t.layout = LayoutTimestamp
+		got := tt.t.AddMonthsClamped(tt.n, tt.opts...)
+		if !got.Equal(tt.want) || got.GetLayout() != LayoutTimestamp {
+			t.Errorf("#%d:: %v.AddMonthsClamped(%d) = %v, want %v", i, tt.t, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestAddYearsClamped(t *testing.T) {
+	tests := [...]struct {
+		t    Toki
+		n    int
+		opts []MonthOption
+		want Toki
+	}{
+		0: {Date(2024, February, 29, 0, 0, 0, 0, UTC), 1, nil, Date(2025, February, 28, 0, 0, 0, 0, UTC)},
+		1: {Date(2024, February, 29, 0, 0, 0, 0, UTC), 4, nil, Date(2028, February, 29, 0, 0, 0, 0, UTC)},
+		2: {Date(2024, February, 29, 0, 0, 0, 0, UTC), -124, nil, Date(1900, February, 28, 0, 0, 0, 0, UTC)},
+		3: {Date(2023, February, 28, 0, 0, 0, 0, UTC), 1, nil, Date(2024, February, 28, 0, 0, 0, 0, UTC)},
+		4: {Date(2023, February, 28, 0, 0, 0, 0, UTC), 1, []MonthOption{SnapToMonthEnd}, Date(2024, February, 29, 0, 0, 0, 0, UTC)},
+	}
+
+	for i, tt := range tests {
+		if got := tt.t.AddYearsClamped(tt.n, tt.opts...); !got.Equal(tt.want) {
+			t.Errorf("#%d:: %v.AddYearsClamped(%d) = %v, want %v", i, tt.t, tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestAddMonthsClampedExhaustive checks every day of a leap-year cycle,
+// including the century years 1900 and 2000, against AddDate.
+func TestAddMonthsClampedExhaustive(t *testing.T) {
+	for _, year := range []int{1899, 1900, 1999, 2000, 2023, 2024} {
+		for d := Date(year, January, 1, 12, 0, 0, 0, UTC); d.Year() == year; d = d.AddDate(0, 0, 1) {
+			y, m, day := d.Date()
+			for n := -25; n <= 25; n++ {
+				for _, snap := range []bool{false, true} {
+					var opts []MonthOption
+					if snap {
+						opts = append(opts, SnapToMonthEnd)
+					}
+					got := d.AddMonthsClamped(n, opts...)
+
+					// The first of the month never overflows with AddDate.
+					want := Date(y, m, 1, 12, 0, 0, 0, UTC).AddDate(0, n, 0)
+					last := DaysIn(want.Month(), want.Year())
+					wantDay := day
+					switch {
+					case day > last:
+						wantDay = last
+					case snap && day == DaysIn(m, y):
+						wantDay = last
+					}
+					want = want.AddDate(0, 0, wantDay-1)
+
+					if !got.Equal(want) {
+						t.Fatalf("%v.AddMonthsClamped(%d, snap=%t) = %v, want %v", d, n, snap, got, want)
+					}
+					if !snap && day <= last && !got.Equal(d.AddDate(0, n, 0)) {
+						t.Fatalf("%v.AddMonthsClamped(%d) = %v, differs from AddDate", d, n, got)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAddMonthsClampedDST(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The wall clock is kept across the DST change.
+	got := Date(2023, January, 31, 9, 30, 0, 0, la).AddMonthsClamped(3)
+	if want := Date(2023, April, 30, 9, 30, 0, 0, la); !got.Equal(want) || got.Hour() != 9 {
+		t.Errorf("AddMonthsClamped(3) = %v, want %v", got, want)
+	}
+}
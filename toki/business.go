@@ -0,0 +1,204 @@
+package toki
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// A Holiday is a named non-business day. LocalName is the name in the
+// language of the country observing it, if different from Name.
+type Holiday struct {
+	Date      CivilDate `json:"date"`
+	Name      string    `json:"name"`
+	LocalName string    `json:"local_name,omitempty"`
+}
+
+// A HolidayProvider reports the holiday falling on a date, if any.
+type HolidayProvider interface {
+	Holiday(d CivilDate) (Holiday, bool)
+}
+
+// A HolidayList is a HolidayProvider backed by a fixed set of holidays.
+type HolidayList map[CivilDate]Holiday
+
+// NewHolidayList returns a HolidayList of holidays.
+func NewHolidayList(holidays ...Holiday) HolidayList {
+	l := make(HolidayList, len(holidays))
+	for _, h := range holidays {
+		l[h.Date] = h
+	}
+	return l
+}
+
+func (l HolidayList) Holiday(d CivilDate) (Holiday, bool) {
+	h, ok := l[d]
+	return h, ok
+}
+
+// LoadHolidaysJSON reads a holiday list from a JSON array of objects
+// with "date" in the YYYY-MM-DD format and "name" members.
+func LoadHolidaysJSON(r io.Reader) (HolidayList, error) {
+	var holidays []Holiday
+	if err := json.NewDecoder(r).Decode(&holidays); err != nil {
+		return nil, fmt.Errorf("toki: reading holidays: %w", err)
+	}
+	return NewHolidayList(holidays...), nil
+}
+
+// LoadHolidaysCSV reads a holiday list from CSV records of a date in the
+// YYYY-MM-DD format followed by an optional name. A header record whose
+// first field is "date" is skipped.
+func LoadHolidaysCSV(r io.Reader) (HolidayList, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("toki: reading holidays: %w", err)
+	}
+
+	var holidays []Holiday
+	for i, rec := range records {
+		if i == 0 && strings.EqualFold(rec[0], "date") {
+			continue
+		}
+		d, err := ParseCivilDate(rec[0])
+		if err != nil {
+			return nil, fmt.Errorf("toki: reading holidays: record %d: %w", i+1, err)
+		}
+		h := Holiday{Date: d}
+		if len(rec) > 1 {
+			h.Name = rec[1]
+		}
+		holidays = append(holidays, h)
+	}
+	return NewHolidayList(holidays...), nil
+}
+
+// LoadHolidaysFile reads a holiday list from the named file, in JSON if
+// its extension is .json and in CSV otherwise.
+func LoadHolidaysFile(name string) (HolidayList, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		return LoadHolidaysJSON(f)
+	}
+	return LoadHolidaysCSV(f)
+}
+
+// A BusinessCalendar defines business days as the days that are neither
+// weekend days nor holidays.
+type BusinessCalendar struct {
+	// Weekend lists the weekly days off. A nil Weekend means Saturday and
+	// Sunday; use an empty slice for a calendar without weekends.
+	Weekend []Weekday
+
+	// Holidays reports additional days off. It may be nil.
+	Holidays HolidayProvider
+}
+
+var defaultWeekend = []Weekday{Saturday, Sunday}
+
+func (c *BusinessCalendar) weekend() []Weekday {
+	if c.Weekend == nil {
+		return defaultWeekend
+	}
+	return c.Weekend
+}
+
+// IsBusinessDay reports whether d is a business day in c.
+func (c *BusinessCalendar) IsBusinessDay(d CivilDate) bool {
+	wd := d.Weekday()
+	for _, w := range c.weekend() {
+		if w == wd {
+			return false
+		}
+	}
+	if c.Holidays != nil {
+		if _, ok := c.Holidays.Holiday(d); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// checkWeekend panics if every day of the week is a weekend day, which
+// would make the search for a business day endless.
+func (c *BusinessCalendar) checkWeekend() {
+	var days [7]bool
+	n := 0
+	for _, w := range c.weekend() {
+		if w >= Sunday && w <= Saturday && !days[w] {
+			days[w] = true
+			n++
+		}
+	}
+	if n == 7 {
+		panic("toki: business calendar without business days")
+	}
+}
+
+// IsBusinessDay reports whether the date of t in t's location is a
+// business day in cal.
+func (t Toki) IsBusinessDay(cal *BusinessCalendar) bool {
+	return cal.IsBusinessDay(CivilDateOf(t))
+}
+
+// AddBusinessDays returns t moved forward by n business days in cal, or
+// backward if n is negative, keeping the wall clock. Only business days
+// after (or before) the date of t are counted, so adding one business day
+// to a Saturday yields the following Monday in a Monday to Friday week.
+func (t Toki) AddBusinessDays(n int, cal *BusinessCalendar) Toki {
+	if n == 0 {
+		return t
+	}
+	cal.checkWeekend()
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	d := CivilDateOf(t)
+	days := 0
+	for n > 0 {
+		days += step
+		if cal.IsBusinessDay(d.AddDays(days)) {
+			n--
+		}
+	}
+	return t.AddDate(0, 0, days)
+}
+
+// NextBusinessDay returns the first business day in cal after the date
+// of t, at the same wall clock.
+func (t Toki) NextBusinessDay(cal *BusinessCalendar) Toki {
+	return t.AddBusinessDays(1, cal)
+}
+
+// BusinessDaysBetween returns the number of business days in cal from
+// the date of t, inclusive, to the date of u, exclusive, both in t's
+// location. It is negative if u is before t.
+func (t Toki) BusinessDaysBetween(u Toki, cal *BusinessCalendar) int {
+	from := CivilDateOf(t)
+	to := CivilDateOf(u.In(t.Location()))
+	sign := 1
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+	n := 0
+	for d := from; d.Before(to); d = d.AddDays(1) {
+		if cal.IsBusinessDay(d) {
+			n++
+		}
+	}
+	return sign * n
+}
@@ -0,0 +1,160 @@
+package toki
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testHolidays = NewHolidayList(
+	Holiday{Date: CivilDate{2023, December, 25}, Name: "Christmas Day"},
+	Holiday{Date: CivilDate{2023, December, 26}, Name: "Boxing Day"},
+	Holiday{Date: CivilDate{2024, January, 1}, Name: "New Year's Day"},
+)
+
+func TestBusinessCalendar(t *testing.T) {
+	cal := &BusinessCalendar{Holidays: testHolidays}
+
+	tests := [...]struct {
+		date CivilDate
+		want bool
+	}{
+		0: {CivilDate{2023, December, 22}, true},  // Friday
+		1: {CivilDate{2023, December, 23}, false}, // Saturday
+		2: {CivilDate{2023, December, 24}, false}, // Sunday
+		3: {CivilDate{2023, December, 25}, false}, // Christmas
+		4: {CivilDate{2023, December, 27}, true},
+	}
+	for i, tt := range tests {
+		if got := cal.IsBusinessDay(tt.date); got != tt.want {
+			t.Errorf("#%d:: IsBusinessDay(%v) = %t, want %t", i, tt.date, got, tt.want)
+		}
+		if got := tt.date.In(UTC).IsBusinessDay(cal); got != tt.want {
+			t.Errorf("#%d:: Toki.IsBusinessDay(%v) = %t, want %t", i, tt.date, got, tt.want)
+		}
+	}
+
+	// A Friday and Saturday weekend, as in much of the Middle East.
+	me := &BusinessCalendar{Weekend: []Weekday{Friday, Saturday}}
+	if me.IsBusinessDay(CivilDate{2023, December, 22}) || !me.IsBusinessDay(CivilDate{2023, December, 24}) {
+		t.Errorf("custom weekend is not honored")
+	}
+	if none := (&BusinessCalendar{Weekend: []Weekday{}}); !none.IsBusinessDay(CivilDate{2023, December, 23}) {
+		t.Errorf("empty weekend is not honored")
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	cal := &BusinessCalendar{Holidays: testHolidays}
+
+	tests := [...]struct {
+		t    Toki
+		n    int
+		want Toki
+	}{
+		0: {Date(2023, December, 20, 9, 0, 0, 0, UTC), 0, Date(2023, December, 20, 9, 0, 0, 0, UTC)},
+		1: {Date(2023, December, 20, 9, 0, 0, 0, UTC), 2, Date(2023, December, 22, 9, 0, 0, 0, UTC)},
+		2: {Date(2023, December, 20, 9, 0, 0, 0, UTC), 5, Date(2023, December, 29, 9, 0, 0, 0, UTC)},
+		3: {Date(2023, December, 29, 9, 0, 0, 0, UTC), 1, Date(2024, January, 2, 9, 0, 0, 0, UTC)},
+		4: {Date(2023, December, 23, 9, 0, 0, 0, UTC), 1, Date(2023, December, 27, 9, 0, 0, 0, UTC)},
+		5: {Date(2024, January, 2, 9, 0, 0, 0, UTC), -1, Date(2023, December, 29, 9, 0, 0, 0, UTC)},
+		6: {Date(2023, December, 27, 9, 0, 0, 0, UTC), -2, Date(2023, December, 21, 9, 0, 0, 0, UTC)},
+	}
+
+	for i, tt := range tests {
+		tt.t.layout = LayoutTimestamp
+		got := tt.t.AddBusinessDays(tt.n, cal)
+		if !got.Equal(tt.want) || got.GetLayout() != LayoutTimestamp {
+			t.Errorf("#%d:: %v.AddBusinessDays(%d) = %v, want %v", i, tt.t, tt.n, got, tt.want)
+		}
+	}
+
+	fri := Date(2023, December, 22, 9, 0, 0, 0, UTC)
+	if got, want := fri.NextBusinessDay(cal), Date(2023, December, 27, 9, 0, 0, 0, UTC); !got.Equal(want) {
+		t.Errorf("NextBusinessDay() = %v, want %v", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AddBusinessDays did not panic without business days")
+		}
+	}()
+	all := &BusinessCalendar{Weekend: []Weekday{Sunday, Monday, Tuesday, Wednesday, Thursday, Friday, Saturday}}
+	fri.AddBusinessDays(1, all)
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	cal := &BusinessCalendar{Holidays: testHolidays}
+
+	a := Date(2023, December, 18, 9, 0, 0, 0, UTC)
+	b := Date(2024, January, 8, 0, 0, 0, 0, UTC)
+	// Three weeks of five days, less three holidays.
+	if got := a.BusinessDaysBetween(b, cal); got != 12 {
+		t.Errorf("BusinessDaysBetween() = %d, want 12", got)
+	}
+	if got := b.BusinessDaysBetween(a, cal); got != -12 {
+		t.Errorf("BusinessDaysBetween() = %d, want -12", got)
+	}
+	if got := a.BusinessDaysBetween(a, cal); got != 0 {
+		t.Errorf("BusinessDaysBetween() = %d, want 0", got)
+	}
+	for n := -15; n <= 15; n++ {
+		if got := a.BusinessDaysBetween(a.AddBusinessDays(n, cal), cal); got != n {
+			t.Errorf("BusinessDaysBetween(AddBusinessDays(%d)) = %d", n, got)
+		}
+	}
+}
+
+func TestLoadHolidays(t *testing.T) {
+	want := NewHolidayList(
+		Holiday{Date: CivilDate{2023, December, 25}, Name: "Christmas Day"},
+		Holiday{Date: CivilDate{2024, January, 1}, Name: "New Year's Day"},
+	)
+
+	const jsonData = `[
+		{"date": "2023-12-25", "name": "Christmas Day"},
+		{"date": "2024-01-01", "name": "New Year's Day"}
+	]`
+	const csvData = "date,name\n2023-12-25,Christmas Day\n# comment\n2024-01-01, \"New Year's Day\"\n"
+
+	check := func(name string, got HolidayList, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %d holidays, want %d", name, len(got), len(want))
+		}
+		for d, h := range want {
+			if g, ok := got.Holiday(d); !ok || g != h {
+				t.Errorf("%s: Holiday(%v) = %v, %t, want %v", name, d, g, ok, h)
+			}
+		}
+	}
+
+	l, err := LoadHolidaysJSON(strings.NewReader(jsonData))
+	check("JSON", l, err)
+	l, err = LoadHolidaysCSV(strings.NewReader(csvData))
+	check("CSV", l, err)
+
+	dir := t.TempDir()
+	for name, data := range map[string]string{"holidays.json": jsonData, "holidays.csv": csvData} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		l, err := LoadHolidaysFile(path)
+		check(name, l, err)
+	}
+
+	if _, err := LoadHolidaysCSV(strings.NewReader("2023-02-30,Bad\n")); err == nil {
+		t.Errorf("LoadHolidaysCSV of an invalid date error = nil")
+	}
+	if _, err := LoadHolidaysJSON(strings.NewReader(`[{"date": "12/25"}]`)); err == nil {
+		t.Errorf("LoadHolidaysJSON of an invalid date error = nil")
+	}
+	if _, err := LoadHolidaysFile(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Errorf("LoadHolidaysFile of a missing file error = nil")
+	}
+}
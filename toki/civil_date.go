@@ -0,0 +1,212 @@
+package toki
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// LayoutCivilDate is the layout of CivilDate in text, JSON and SQL.
+const LayoutCivilDate = "2006-01-02"
+
+// A CivilDate is a date in the proleptic Gregorian calendar, without a
+// time of day or a location. It suits birthdays and due dates, which do
+// not denote an instant.
+type CivilDate struct {
+	Year  int
+	Month Month
+	Day   int
+}
+
+// CivilDateOf returns the date of t in t's location.
+func CivilDateOf(t Toki) CivilDate {
+	y, m, d := t.Date()
+	return CivilDate{Year: y, Month: m, Day: d}
+}
+
+// Today returns the current date in loc, read from the package-level Clock.
+func Today(loc *Location) CivilDate {
+	return CivilDateOf(Toki{Time: DefaultClock().Now().In(loc)})
+}
+
+// ParseCivilDate parses a date in the YYYY-MM-DD format.
+func ParseCivilDate(s string) (CivilDate, error) {
+	t, err := time.Parse(LayoutCivilDate, s)
+	if err != nil {
+		return CivilDate{}, err
+	}
+	return CivilDateOf(Toki{Time: t}), nil
+}
+
+func (d CivilDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// IsValid reports whether d names an existing day.
+func (d CivilDate) IsValid() bool {
+	return d.Month >= January && d.Month <= December && d.Day >= 1 && d.Day <= DaysIn(d.Month, d.Year)
+}
+
+func (d CivilDate) IsZero() bool {
+	return d == CivilDate{}
+}
+
+// In returns the first instant of d in loc. If midnight does not exist in
+// loc on that day because of a transition, the first instant after the
+// transition is returned.
+func (d CivilDate) In(loc *Location, layouts ...string) Toki {
+	if !d.IsValid() {
+		d = d.AddDays(0)
+	}
+	t := time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+	if t.Day() != d.Day {
+		// time.Date resolved a skipped midnight to the previous day.
+		_, end := t.ZoneBounds()
+		t = end
+	}
+	return Toki{layout: setLayout(layouts...), Time: t}
+}
+
+// AddDays returns d shifted by n days.
+func (d CivilDate) AddDays(n int) CivilDate {
+	return CivilDateOf(Toki{Time: time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, UTC)})
+}
+
+// AddMonths returns d shifted by n months. Like AddDate, it normalizes
+// overflowing days, so that October 31 plus one month is December 1.
+func (d CivilDate) AddMonths(n int) CivilDate {
+	return CivilDateOf(Toki{Time: time.Date(d.Year, d.Month+Month(n), d.Day, 0, 0, 0, 0, UTC)})
+}
+
+// DaysSince returns the number of days from s to d, negative if d is
+// before s.
+func (d CivilDate) DaysSince(s CivilDate) int {
+	return int(d.absDays() - s.absDays())
+}
+
+func (d CivilDate) Before(u CivilDate) bool {
+	return d.Compare(u) < 0
+}
+
+func (d CivilDate) After(u CivilDate) bool {
+	return d.Compare(u) > 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether d is before, equal to
+// or after u.
+func (d CivilDate) Compare(u CivilDate) int {
+	switch {
+	case d.Year != u.Year:
+		return cmpInt(d.Year, u.Year)
+	case d.Month != u.Month:
+		return cmpInt(int(d.Month), int(u.Month))
+	}
+	return cmpInt(d.Day, u.Day)
+}
+
+func (d CivilDate) Weekday() Weekday {
+	return d.time().Weekday()
+}
+
+func (d CivilDate) ISOWeek() (year, week int) {
+	return d.time().ISOWeek()
+}
+
+func (d CivilDate) YearDay() int {
+	return d.time().YearDay()
+}
+
+func (d CivilDate) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *CivilDate) UnmarshalText(data []byte) error {
+	v, err := ParseCivilDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+func (d CivilDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *CivilDate) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("CivilDate.UnmarshalJSON: %w", err)
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// Scan implements the sql.Scanner interface. It accepts DATE columns
+// decoded as time.Time as well as YYYY-MM-DD strings.
+func (d *CivilDate) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = CivilDate{}
+		return nil
+	case time.Time:
+		*d = CivilDateOf(Toki{Time: v})
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	}
+	return fmt.Errorf("CivilDate.Scan: unsupported type %T", src)
+}
+
+// Value implements the driver.Valuer interface. The zero CivilDate is
+// NULL, like Scan reads it.
+func (d CivilDate) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	if !d.IsValid() {
+		return nil, fmt.Errorf("CivilDate.Value: invalid date %s", d)
+	}
+	return d.String(), nil
+}
+
+func (d CivilDate) time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, UTC)
+}
+
+// absDays returns the number of days from January 1 of year 1 to d.
+func (d CivilDate) absDays() int64 {
+	if !d.IsValid() {
+		d = d.AddDays(0)
+	}
+	y := int64(d.Year) - 1
+	n := y*365 + floorDiv(y, 4) - floorDiv(y, 100) + floorDiv(y, 400)
+	n += int64(daysBefore[d.Month-1])
+	if d.Month > February && isLeap(d.Year) {
+		n++
+	}
+	return n + int64(d.Day) - 1
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,196 @@
+package toki
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCivilDateIsValid(t *testing.T) {
+	tests := [...]struct {
+		date CivilDate
+		want bool
+	}{
+		0: {CivilDate{2023, October, 16}, true},
+		1: {CivilDate{2024, February, 29}, true},
+		2: {CivilDate{2023, February, 29}, false},
+		3: {CivilDate{1900, February, 29}, false},
+		4: {CivilDate{2000, February, 29}, true},
+		5: {CivilDate{2023, 13, 1}, false},
+		6: {CivilDate{2023, 0, 1}, false},
+		7: {CivilDate{2023, April, 31}, false},
+		8: {CivilDate{2023, April, 0}, false},
+		9: {CivilDate{}, false},
+	}
+
+	for i, tt := range tests {
+		if got := tt.date.IsValid(); got != tt.want {
+			t.Errorf("#%d:: %v.IsValid() = %t, want %t", i, tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestCivilDateArithmetic(t *testing.T) {
+	addDaysTests := [...]struct {
+		date CivilDate
+		n    int
+		want CivilDate
+	}{
+		0: {CivilDate{2023, October, 16}, 0, CivilDate{2023, October, 16}},
+		1: {CivilDate{2023, October, 16}, 16, CivilDate{2023, November, 1}},
+		2: {CivilDate{2023, December, 31}, 1, CivilDate{2024, January, 1}},
+		3: {CivilDate{2024, March, 1}, -1, CivilDate{2024, February, 29}},
+		4: {CivilDate{2023, March, 1}, -1, CivilDate{2023, February, 28}},
+		5: {CivilDate{2000, January, 1}, 366, CivilDate{2001, January, 1}},
+		6: {CivilDate{2023, February, 30}, 0, CivilDate{2023, March, 2}},
+	}
+	for i, tt := range addDaysTests {
+		if got := tt.date.AddDays(tt.n); got != tt.want {
+			t.Errorf("#%d:: %v.AddDays(%d) = %v, want %v", i, tt.date, tt.n, got, tt.want)
+		}
+		if tt.date.IsValid() {
+			if got := tt.want.DaysSince(tt.date); got != tt.n {
+				t.Errorf("#%d:: %v.DaysSince(%v) = %d, want %d", i, tt.want, tt.date, got, tt.n)
+			}
+		}
+	}
+
+	addMonthsTests := [...]struct {
+		date CivilDate
+		n    int
+		want CivilDate
+	}{
+		0: {CivilDate{2023, October, 16}, 1, CivilDate{2023, November, 16}},
+		1: {CivilDate{2023, October, 31}, 1, CivilDate{2023, December, 1}},
+		2: {CivilDate{2023, January, 31}, -2, CivilDate{2022, December, 1}},
+		3: {CivilDate{2023, October, 16}, 15, CivilDate{2025, January, 16}},
+	}
+	for i, tt := range addMonthsTests {
+		if got := tt.date.AddMonths(tt.n); got != tt.want {
+			t.Errorf("#%d:: %v.AddMonths(%d) = %v, want %v", i, tt.date, tt.n, got, tt.want)
+		}
+	}
+
+	// DaysSince agrees with time across a wide range, including negative years.
+	base := CivilDate{1970, January, 1}
+	for _, y := range []int{-1000, -1, 0, 1, 1582, 1900, 2000, 2038, 9999, 100000} {
+		for _, m := range []Month{January, February, March, December} {
+			d := CivilDate{y, m, 28}
+			want := int(d.time().Sub(base.time()).Hours() / 24)
+			if y < 1700 || y > 2200 {
+				// Avoid overflowing time.Duration.
+				want = int(d.time().Unix()/secondsPerDay - base.time().Unix()/secondsPerDay)
+			}
+			if got := d.DaysSince(base); got != want {
+				t.Errorf("%v.DaysSince(%v) = %d, want %d", d, base, got, want)
+			}
+		}
+	}
+}
+
+func TestCivilDateCalendar(t *testing.T) {
+	for _, wt := range isoWeekTests {
+		d := CivilDate{wt.year, Month(wt.month), wt.day}
+		if y, w := d.ISOWeek(); y != wt.yex || w != wt.wex {
+			t.Errorf("%v.ISOWeek() = %d/%d, want %d/%d", d, y, w, wt.yex, wt.wex)
+		}
+	}
+	for _, ydt := range yearDayTests {
+		d := CivilDate{ydt.year, Month(ydt.month), ydt.day}
+		if got := d.YearDay(); got != ydt.yday {
+			t.Errorf("%v.YearDay() = %d, want %d", d, got, ydt.yday)
+		}
+	}
+	if got := (CivilDate{2023, October, 16}).Weekday(); got != Monday {
+		t.Errorf("Weekday() = %v, want Monday", got)
+	}
+
+	a, b := CivilDate{2023, October, 16}, CivilDate{2023, November, 1}
+	if !a.Before(b) || a.After(b) || a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Errorf("comparison of %v and %v is inconsistent", a, b)
+	}
+}
+
+func TestCivilDateIn(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [...]struct {
+		date CivilDate
+		loc  *Location
+		want Toki
+	}{
+		0: {CivilDate{2023, October, 16}, UTC, Date(2023, October, 16, 0, 0, 0, 0, UTC)},
+		1: {CivilDate{2023, October, 16}, tokyo, Date(2023, October, 15, 15, 0, 0, 0, UTC)},
+		// Midnight was skipped when DST started in Brazil.
+		2: {CivilDate{2018, November, 4}, saoPaulo, Date(2018, November, 4, 3, 0, 0, 0, UTC)},
+	}
+
+	for i, tt := range tests {
+		got := tt.date.In(tt.loc, LayoutTimestamp)
+		if !got.Equal(tt.want) || got.Location() != tt.loc || got.GetLayout() != LayoutTimestamp {
+			t.Errorf("#%d:: %v.In(%v) = %v, want %v", i, tt.date, tt.loc, got, tt.want)
+		}
+		if back := CivilDateOf(got); back != tt.date {
+			t.Errorf("#%d:: CivilDateOf(%v) = %v, want %v", i, got, back, tt.date)
+		}
+	}
+}
+
+func TestCivilDateEncoding(t *testing.T) {
+	d := CivilDate{2023, October, 6}
+
+	b, err := json.Marshal(d)
+	if err != nil || string(b) != `"2023-10-06"` {
+		t.Errorf("json.Marshal(%v) = %s, %v", d, b, err)
+	}
+	var got CivilDate
+	if err := json.Unmarshal(b, &got); err != nil || got != d {
+		t.Errorf("json.Unmarshal(%s) = %v, %v", b, got, err)
+	}
+	if err := json.Unmarshal([]byte(`"2023-02-29"`), &got); err == nil {
+		t.Errorf("json.Unmarshal of an invalid date error = nil")
+	}
+	if err := json.Unmarshal([]byte(`20231006`), &got); err == nil {
+		t.Errorf("json.Unmarshal of a number error = nil")
+	}
+
+	v, err := d.Value()
+	if err != nil || v != "2023-10-06" {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+	if _, err := (CivilDate{2023, February, 29}).Value(); err == nil {
+		t.Errorf("Value() of an invalid date error = nil")
+	}
+
+	scanTests := []interface{}{
+		"2023-10-06",
+		[]byte("2023-10-06"),
+		time.Date(2023, October, 6, 0, 0, 0, 0, UTC),
+	}
+	for _, src := range scanTests {
+		var got CivilDate
+		if err := got.Scan(src); err != nil || got != d {
+			t.Errorf("Scan(%#v) = %v, %v", src, got, err)
+		}
+	}
+	if err := got.Scan(42); err == nil {
+		t.Errorf("Scan(42) error = nil")
+	}
+
+	// The zero date is NULL.
+	v, err = CivilDate{}.Value()
+	if err != nil || v != nil {
+		t.Errorf("Value() of the zero date = %v, %v, want nil", v, err)
+	}
+	got = d
+	if err := got.Scan(v); err != nil || !got.IsZero() {
+		t.Errorf("Scan(%#v) = %v, %v", v, got, err)
+	}
+}
@@ -0,0 +1,143 @@
+package toki
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// A Clock tells the current time and creates timers. Now, NowCtx and
+	// the NowTimeStamp functions read the time from a Clock so that code
+	// built on them can be tested with a fake one.
+	//
+	// If a Clock also has a Layout() string method, Now and NowCtx use
+	// its result when no layout is given.
+	Clock interface {
+		Now() time.Time
+		Since(t time.Time) time.Duration
+		Until(t time.Time) time.Duration
+		After(d time.Duration) <-chan time.Time
+		NewTimer(d time.Duration) Timer
+		NewTicker(d time.Duration) Ticker
+	}
+
+	// A Timer is a single event created by a Clock, like time.Timer.
+	Timer interface {
+		C() <-chan time.Time
+		Stop() bool
+		Reset(d time.Duration) bool
+	}
+
+	// A Ticker delivers ticks at intervals, like time.Ticker.
+	Ticker interface {
+		C() <-chan time.Time
+		Stop()
+		Reset(d time.Duration)
+	}
+)
+
+type clockKey struct{}
+
+// clockHolder wraps a Clock so that clocks of different types can be
+// stored in the same atomic.Value.
+type clockHolder struct {
+	Clock
+}
+
+var defaultClock atomic.Value
+
+func init() {
+	defaultClock.Store(clockHolder{SystemClock()})
+}
+
+// SystemClock returns the Clock backed by the time package.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// DefaultClock returns the package-level Clock.
+func DefaultClock() Clock {
+	return defaultClock.Load().(clockHolder).Clock
+}
+
+// SetClock replaces the package-level Clock and returns a function that
+// restores the previous one. A nil c restores the system clock.
+func SetClock(c Clock) (restore func()) {
+	if c == nil {
+		c = SystemClock()
+	}
+	prev := defaultClock.Swap(clockHolder{c})
+	return func() {
+		defaultClock.Store(prev)
+	}
+}
+
+// WithClock returns a copy of ctx carrying c, which ClockFrom and NowCtx
+// prefer over the package-level Clock.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// ClockFrom returns the Clock carried by ctx, or the package-level Clock.
+func ClockFrom(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok && c != nil {
+		return c
+	}
+	return DefaultClock()
+}
+
+func NowCtx(ctx context.Context, layouts ...string) Toki {
+	return nowFrom(ClockFrom(ctx), layouts...)
+}
+
+func nowFrom(c Clock, layouts ...string) Toki {
+	if len(layouts) == 0 {
+		if lc, ok := c.(interface{ Layout() string }); ok {
+			layouts = []string{lc.Layout()}
+		}
+	}
+	return Toki{layout: setLayout(layouts...), Time: inDefaultLocation(c.Now())}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (systemClock) Until(t time.Time) time.Duration {
+	return time.Until(t)
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
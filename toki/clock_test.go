@@ -0,0 +1,106 @@
+package toki
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// stoppedClock is a Clock whose time never moves.
+type stoppedClock struct {
+	systemClock
+	now time.Time
+}
+
+func (c stoppedClock) Now() time.Time {
+	return c.now
+}
+
+func TestSetClock(t *testing.T) {
+	at := time.Date(2023, October, 16, 10, 15, 0, 0, UTC)
+	restore := SetClock(stoppedClock{now: at})
+
+	if got := Now(); !got.Time.Equal(at) {
+		t.Errorf("Now() = %v, want %v", got, at)
+	}
+	if got := Now(LayoutTimestamp); got.GetLayout() != LayoutTimestamp {
+		t.Errorf("Now(%q).GetLayout() = %q", LayoutTimestamp, got.GetLayout())
+	}
+	if got := NowTimeStamp(); !got.Time.Equal(at) {
+		t.Errorf("NowTimeStamp() = %v, want %v", got.Time, at)
+	}
+	if got := NowTimeStampMilli(); !got.Time.Equal(at) {
+		t.Errorf("NowTimeStampMilli() = %v, want %v", got.Time, at)
+	}
+	if got := NowTimeStampNano(); !got.Time.Equal(at) {
+		t.Errorf("NowTimeStampNano() = %v, want %v", got.Time, at)
+	}
+
+	restore()
+	if _, ok := DefaultClock().(systemClock); !ok {
+		t.Errorf("DefaultClock() after restore = %T, want systemClock", DefaultClock())
+	}
+
+	SetClock(stoppedClock{now: at})
+	SetClock(nil)
+	if _, ok := DefaultClock().(systemClock); !ok {
+		t.Errorf("DefaultClock() after SetClock(nil) = %T, want systemClock", DefaultClock())
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	at := time.Date(2023, October, 16, 10, 15, 0, 0, UTC)
+	ctx := WithClock(context.Background(), stoppedClock{now: at})
+
+	if got := NowCtx(ctx); !got.Time.Equal(at) {
+		t.Errorf("NowCtx() = %v, want %v", got, at)
+	}
+	if got := NowCtx(ctx, LayoutTimestampMilli); got.GetLayout() != LayoutTimestampMilli {
+		t.Errorf("NowCtx(%q).GetLayout() = %q", LayoutTimestampMilli, got.GetLayout())
+	}
+	if _, ok := ClockFrom(context.Background()).(systemClock); !ok {
+		t.Errorf("ClockFrom(context.Background()) = %T, want systemClock", ClockFrom(context.Background()))
+	}
+	// The package-level clock is left alone.
+	if got := Now(); got.Time.Equal(at) {
+		t.Errorf("Now() = %v, want the system time", got)
+	}
+}
+
+func TestSystemClockTimers(t *testing.T) {
+	c := SystemClock()
+
+	timer := c.NewTimer(time.Millisecond)
+	select {
+	case <-timer.C():
+	case <-time.After(time.Second):
+		t.Fatal("timer did not fire")
+	}
+	if timer.Stop() {
+		t.Errorf("Stop() of a fired timer = true")
+	}
+
+	ticker := c.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ticker.C():
+		case <-time.After(time.Second):
+			t.Fatal("ticker did not tick")
+		}
+	}
+
+	select {
+	case <-c.After(time.Millisecond):
+	case <-time.After(time.Second):
+		t.Fatal("After did not fire")
+	}
+
+	start := c.Now()
+	if d := c.Since(start); d < 0 {
+		t.Errorf("Since(Now()) = %v, want >= 0", d)
+	}
+	if d := c.Until(start.Add(time.Hour)); d <= 0 || d > time.Hour {
+		t.Errorf("Until(Now()+1h) = %v", d)
+	}
+}
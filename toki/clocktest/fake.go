@@ -0,0 +1,278 @@
+// Package clocktest provides a fake toki.Clock for tests.
+package clocktest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+// A FakeClock is a toki.Clock whose time only moves when Advance or Set is
+// called. Timers and tickers fire in deadline order, ties broken by
+// creation order, as the clock passes their deadlines.
+//
+// A FakeClock is safe for concurrent use by multiple goroutines.
+type FakeClock struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	layout  string
+	seq     uint64
+	waiters []*waiter
+
+	// onFire, if not nil, is called with each waiter as it fires. It
+	// lets tests record the firing order within a single Advance.
+	onFire func(w *waiter)
+}
+
+var _ toki.Clock = (*FakeClock)(nil)
+
+// waiter is a pending timer, ticker or After channel.
+type waiter struct {
+	clock  *FakeClock
+	ch     chan time.Time
+	when   time.Time
+	period time.Duration // zero for timers
+	seq    uint64
+}
+
+// NewFakeClock returns a FakeClock set to now. Times returned by the clock
+// are in now's location. The optional layout is the default layout of the
+// Toki values returned by Toki and by toki.Now when the clock is in use.
+func NewFakeClock(now time.Time, layouts ...string) *FakeClock {
+	c := &FakeClock{now: now}
+	if len(layouts) > 0 {
+		c.layout = layouts[0]
+	}
+	c.changed = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Toki returns the current time of the clock with the clock's layout.
+func (c *FakeClock) Toki() toki.Toki {
+	t := toki.New(c.Layout())
+	t.Time = c.Now()
+	return t
+}
+
+// Layout returns the default layout of the clock.
+func (c *FakeClock) Layout() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.layout == "" {
+		return toki.RFC3339
+	}
+	return c.layout
+}
+
+// SetLayout changes the default layout of the clock.
+func (c *FakeClock) SetLayout(layout string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.layout = layout
+}
+
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *FakeClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// Sleep blocks until the clock has been advanced by d.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) toki.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &waiter{clock: c, ch: make(chan time.Time, 1)}
+	c.schedule(w, d)
+	return (*fakeTimer)(w)
+}
+
+func (c *FakeClock) NewTicker(d time.Duration) toki.Ticker {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &waiter{clock: c, ch: make(chan time.Time, 1), period: d}
+	c.schedule(w, d)
+	return (*fakeTicker)(w)
+}
+
+// Advance moves the clock forward by d, firing every timer and ticker whose
+// deadline is passed on the way. A ticker fires at most once per call,
+// at its first deadline passed, and the ticks it misses are dropped.
+// Negative durations are ignored.
+func (c *FakeClock) Advance(d time.Duration) {
+	if d < 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advanceTo(c.now.Add(d))
+}
+
+// Set moves the clock to t. If t is after the current time, timers and
+// tickers fire as with Advance; otherwise the clock is set back and no
+// timer fires.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.After(c.now) {
+		c.advanceTo(t)
+		return
+	}
+	c.now = t.In(c.now.Location())
+}
+
+// BlockUntil blocks until at least n timers, tickers, After or Sleep calls
+// are waiting on the clock.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.changed.Wait()
+	}
+}
+
+// Waiters returns the number of timers, tickers, After and Sleep calls
+// waiting on the clock.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// advanceTo fires the waiters due until t and sets the clock to t.
+// c.mu must be held.
+func (c *FakeClock) advanceTo(t time.Time) {
+	for len(c.waiters) > 0 && !c.waiters[0].when.After(t) {
+		w := c.waiters[0]
+		c.waiters = c.waiters[1:]
+		c.now = w.when
+		if c.onFire != nil {
+			c.onFire(w)
+		}
+		select {
+		case w.ch <- w.when:
+		default:
+			// Like time.Ticker, drop ticks for slow receivers.
+		}
+		if w.period > 0 {
+			// Like time.Ticker, skip the ticks missed until t rather
+			// than sending them one at a time.
+			w.when = w.when.Add(t.Sub(w.when) / w.period * w.period).Add(w.period)
+			c.seq++
+			w.seq = c.seq
+			c.insert(w)
+		}
+	}
+	c.now = t.In(c.now.Location())
+	c.changed.Broadcast()
+}
+
+// schedule arms w to fire after d. A non-positive d fires immediately.
+// c.mu must be held.
+func (c *FakeClock) schedule(w *waiter, d time.Duration) {
+	c.seq++
+	w.seq = c.seq
+	w.when = c.now.Add(d)
+	if d <= 0 && w.period == 0 {
+		select {
+		case w.ch <- w.when:
+		default:
+		}
+		return
+	}
+	c.insert(w)
+	c.changed.Broadcast()
+}
+
+// insert adds w to the waiters, keeping them sorted.
+// c.mu must be held.
+func (c *FakeClock) insert(w *waiter) {
+	i := sort.Search(len(c.waiters), func(i int) bool {
+		o := c.waiters[i]
+		return o.when.After(w.when) || (o.when.Equal(w.when) && o.seq > w.seq)
+	})
+	c.waiters = append(c.waiters, nil)
+	copy(c.waiters[i+1:], c.waiters[i:])
+	c.waiters[i] = w
+}
+
+// remove removes w from the waiters, reporting whether it was waiting.
+// c.mu must be held.
+func (c *FakeClock) remove(w *waiter) bool {
+	for i, o := range c.waiters {
+		if o == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.changed.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer waiter
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remove((*waiter)(t))
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	active := c.remove((*waiter)(t))
+	c.schedule((*waiter)(t), d)
+	return active
+}
+
+type fakeTicker waiter
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTicker) Stop() {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove((*waiter)(t))
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for Ticker.Reset")
+	}
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove((*waiter)(t))
+	t.period = d
+	c.schedule((*waiter)(t), d)
+}
@@ -0,0 +1,255 @@
+package clocktest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+var start = time.Date(2023, time.October, 16, 10, 15, 0, 0, time.UTC)
+
+func TestFakeClockNow(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewFakeClock(start.In(la), toki.LayoutTimestamp)
+	if got := c.Now(); !got.Equal(start) || got.Location() != la {
+		t.Errorf("Now() = %v, want %v", got, start.In(la))
+	}
+
+	c.Advance(90 * time.Minute)
+	if got, want := c.Now(), start.Add(90*time.Minute); !got.Equal(want) {
+		t.Errorf("Now() after Advance = %v, want %v", got, want)
+	}
+	if got := c.Since(start); got != 90*time.Minute {
+		t.Errorf("Since(start) = %v, want %v", got, 90*time.Minute)
+	}
+	if got := c.Until(start.Add(2 * time.Hour)); got != 30*time.Minute {
+		t.Errorf("Until(start+2h) = %v, want %v", got, 30*time.Minute)
+	}
+
+	c.Set(start)
+	if got := c.Now(); !got.Equal(start) || got.Location() != la {
+		t.Errorf("Now() after Set = %v, want %v", got, start.In(la))
+	}
+
+	tk := c.Toki()
+	if tk.GetLayout() != toki.LayoutTimestamp || !tk.Time.Equal(start) {
+		t.Errorf("Toki() = %v with layout %q", tk, tk.GetLayout())
+	}
+
+	// toki.Now picks up the clock's layout unless one is given.
+	ctx := toki.WithClock(context.Background(), c)
+	if got := toki.NowCtx(ctx); got.GetLayout() != toki.LayoutTimestamp || !got.Time.Equal(start) {
+		t.Errorf("NowCtx() = %v with layout %q", got, got.GetLayout())
+	}
+	if got := toki.NowCtx(ctx, toki.RFC3339); got.GetLayout() != toki.RFC3339 {
+		t.Errorf("NowCtx(RFC3339).GetLayout() = %q", got.GetLayout())
+	}
+	c.SetLayout(toki.LayoutTimestampMilli)
+	if got := c.Toki(); got.GetLayout() != toki.LayoutTimestampMilli {
+		t.Errorf("Toki().GetLayout() after SetLayout = %q", got.GetLayout())
+	}
+}
+
+func TestFakeClockTimers(t *testing.T) {
+	c := NewFakeClock(start)
+
+	t3 := c.NewTimer(3 * time.Second)
+	t1 := c.NewTimer(1 * time.Second)
+	t2 := c.NewTimer(2 * time.Second)
+	stopped := c.NewTimer(2 * time.Second)
+	if !stopped.Stop() {
+		t.Errorf("Stop() of a pending timer = false")
+	}
+	if c.Waiters() != 3 {
+		t.Errorf("Waiters() = %d, want 3", c.Waiters())
+	}
+
+	c.Advance(1500 * time.Millisecond)
+	assertFired(t, "t1", t1.C(), start.Add(time.Second))
+	assertPending(t, "t2", t2.C())
+
+	c.Advance(2 * time.Second)
+	assertFired(t, "t2", t2.C(), start.Add(2*time.Second))
+	assertFired(t, "t3", t3.C(), start.Add(3*time.Second))
+	assertPending(t, "stopped", stopped.C())
+
+	if t1.Stop() {
+		t.Errorf("Stop() of a fired timer = true")
+	}
+	if t1.Reset(time.Second) {
+		t.Errorf("Reset() of a fired timer = true")
+	}
+	c.Advance(time.Second)
+	assertFired(t, "t1 after Reset", t1.C(), start.Add(4500*time.Millisecond))
+
+	select {
+	case <-c.After(0):
+	default:
+		t.Errorf("After(0) did not fire immediately")
+	}
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	c := NewFakeClock(start)
+	tick := c.NewTicker(time.Minute)
+
+	for i := 1; i <= 3; i++ {
+		c.Advance(time.Minute)
+		assertFired(t, "tick", tick.C(), start.Add(time.Duration(i)*time.Minute))
+	}
+
+	// Ticks are dropped for slow receivers.
+	c.Advance(5 * time.Minute)
+	assertFired(t, "tick", tick.C(), start.Add(4*time.Minute))
+	assertPending(t, "tick", tick.C())
+
+	tick.Reset(time.Hour)
+	c.Advance(59 * time.Minute)
+	assertPending(t, "tick after Reset", tick.C())
+	c.Advance(time.Minute)
+	assertFired(t, "tick after Reset", tick.C(), start.Add(68*time.Minute))
+
+	tick.Stop()
+	c.Advance(2 * time.Hour)
+	assertPending(t, "stopped tick", tick.C())
+	if c.Waiters() != 0 {
+		t.Errorf("Waiters() = %d, want 0", c.Waiters())
+	}
+}
+
+func TestFakeClockBlockUntil(t *testing.T) {
+	c := NewFakeClock(start)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Sleep(time.Duration(i+1) * time.Second)
+		}(i)
+	}
+
+	// BlockUntil waits for the goroutines to start sleeping, so that
+	// Advance cannot run before they do.
+	c.BlockUntil(3)
+	c.Advance(3 * time.Second)
+	wg.Wait()
+
+	if c.Waiters() != 0 {
+		t.Errorf("Waiters() = %d, want 0", c.Waiters())
+	}
+}
+
+func TestFakeClockFiringOrder(t *testing.T) {
+	c := NewFakeClock(start)
+
+	// A ticker interleaves with timers according to its deadlines.
+	tick := c.NewTicker(2 * time.Second)
+	tm := c.NewTimer(3 * time.Second)
+
+	var got []string
+	c.Advance(2 * time.Second)
+	got = append(got, drain(tick.C(), "tick"), drain(tm.C(), "timer"))
+	c.Advance(time.Second)
+	got = append(got, drain(tick.C(), "tick"), drain(tm.C(), "timer"))
+	c.Advance(time.Second)
+	got = append(got, drain(tick.C(), "tick"), drain(tm.C(), "timer"))
+
+	want := []string{"tick", "", "", "timer", "tick", ""}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("firing sequence = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestFakeClockFiringOrderInAdvance(t *testing.T) {
+	c := NewFakeClock(start)
+	names := map[<-chan time.Time]string{}
+	var got []string
+	c.onFire = func(w *waiter) {
+		got = append(got, names[w.ch]+"@"+w.when.Sub(start).String())
+	}
+
+	tm1 := c.NewTimer(time.Second)
+	tickA := c.NewTicker(2 * time.Second)
+	tickB := c.NewTicker(3 * time.Second)
+	tm4a := c.NewTimer(4 * time.Second)
+	tm4b := c.NewTimer(4 * time.Second)
+	names[tm1.C()], names[tickA.C()], names[tickB.C()] = "timer", "tickA", "tickB"
+	names[tm4a.C()], names[tm4b.C()] = "timer4a", "timer4b"
+
+	// A single Advance crosses every deadline in order, ties in creation
+	// order, and each ticker fires once at its first deadline.
+	c.Advance(5 * time.Second)
+	want := []string{"timer@1s", "tickA@2s", "tickB@3s", "timer4a@4s", "timer4b@4s"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("firing order = %q, want %q", got, want)
+	}
+	assertFired(t, "tickA", tickA.C(), start.Add(2*time.Second))
+	assertFired(t, "tickB", tickB.C(), start.Add(3*time.Second))
+
+	// The tickers resume on their own schedules after the missed ticks.
+	got = nil
+	c.Advance(time.Second)
+	want = []string{"tickA@6s", "tickB@6s"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("firing order = %q, want %q", got, want)
+	}
+}
+
+func TestFakeClockTickerLongAdvance(t *testing.T) {
+	c := NewFakeClock(start)
+	tick := c.NewTicker(time.Nanosecond)
+
+	// Advancing far past a short ticker skips its missed ticks at once.
+	c.Advance(time.Hour)
+	assertFired(t, "tick", tick.C(), start.Add(time.Nanosecond))
+	assertPending(t, "tick", tick.C())
+	c.Advance(time.Nanosecond)
+	assertFired(t, "tick", tick.C(), start.Add(time.Hour+time.Nanosecond))
+
+	c.Set(start.AddDate(500, 0, 0))
+	assertFired(t, "tick", tick.C(), start.Add(time.Hour+2*time.Nanosecond))
+	if got := c.Now(); !got.Equal(start.AddDate(500, 0, 0)) {
+		t.Errorf("Now() = %v, want %v", got, start.AddDate(500, 0, 0))
+	}
+}
+
+func drain(ch <-chan time.Time, name string) string {
+	select {
+	case <-ch:
+		return name
+	default:
+		return ""
+	}
+}
+
+func assertFired(t *testing.T, name string, ch <-chan time.Time, want time.Time) {
+	t.Helper()
+	select {
+	case got := <-ch:
+		if !got.Equal(want) {
+			t.Errorf("%s fired at %v, want %v", name, got, want)
+		}
+	default:
+		t.Errorf("%s did not fire", name)
+	}
+}
+
+func assertPending(t *testing.T, name string, ch <-chan time.Time) {
+	t.Helper()
+	select {
+	case got := <-ch:
+		t.Errorf("%s fired at %v, want pending", name, got)
+	default:
+	}
+}
@@ -0,0 +1,308 @@
+// Package cron parses cron expressions and computes the times at which
+// they fire, as toki.Toki values.
+package cron
+
+import (
+	"math/bits"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+// horizon is how far Next and Prev search, in years. The Gregorian
+// calendar repeats every 400 years, so a schedule that does not fire
+// within that span never does.
+const horizon = 400
+
+// A Schedule is a parsed cron expression, evaluated on the wall clock of
+// its location.
+//
+// A wall clock time skipped by a transition, such as 02:30 on the day
+// daylight saving time starts in most of the United States, fires at the
+// first instant after the transition. A time repeated when the clock is
+// set back fires once, at its first occurrence.
+type Schedule struct {
+	spec    string
+	loc     *time.Location
+	second  uint64
+	minute  uint64
+	hour    uint64
+	dom     dayOfMonth
+	month   uint64
+	dow     dayOfWeek
+	domStar bool
+	dowStar bool
+}
+
+// A dayOfMonth is a parsed day of month field.
+type dayOfMonth struct {
+	bits           uint64 // days 1 to 31
+	fromLast       []int  // L-n
+	nearestWeekday []int  // nW
+	lastWeekday    bool   // LW
+}
+
+// A dayOfWeek is a parsed day of week field.
+type dayOfWeek struct {
+	bits uint64   // weekdays 0 to 6
+	last uint64   // weekdays of nL
+	nth  [7]uint8 // occurrences 1 to 5 of n#k, by weekday
+}
+
+// String returns the expression s was parsed from.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Location returns the location in which s is evaluated.
+func (s *Schedule) Location() *time.Location {
+	return s.loc
+}
+
+func (f *dayOfMonth) matches(d toki.CivilDate, last int) bool {
+	if f.bits&(1<<uint(d.Day)) != 0 {
+		return true
+	}
+	for _, n := range f.fromLast {
+		if d.Day == last-n {
+			return true
+		}
+	}
+	for _, n := range f.nearestWeekday {
+		if n <= last && d.Day == nearestWeekday(withDay(d, n), last) {
+			return true
+		}
+	}
+	return f.lastWeekday && d.Day == nearestWeekday(withDay(d, last), last)
+}
+
+func (f *dayOfWeek) matches(d toki.CivilDate, last int) bool {
+	wd := d.Weekday()
+	switch {
+	case f.bits&(1<<uint(wd)) != 0:
+		return true
+	case f.last&(1<<uint(wd)) != 0 && d.Day+7 > last:
+		return true
+	}
+	return f.nth[wd]&(1<<uint((d.Day-1)/7+1)) != 0
+}
+
+// nearestWeekday returns the day of the weekday, Monday to Friday,
+// nearest to d without leaving its month.
+func nearestWeekday(d toki.CivilDate, last int) int {
+	switch d.Weekday() {
+	case time.Saturday:
+		if d.Day == 1 {
+			return 3
+		}
+		return d.Day - 1
+	case time.Sunday:
+		if d.Day == last {
+			return d.Day - 2
+		}
+		return d.Day + 1
+	}
+	return d.Day
+}
+
+func (s *Schedule) dayMatches(d toki.CivilDate) bool {
+	if s.month&(1<<uint(d.Month)) == 0 {
+		return false
+	}
+	last := daysInMonth(d)
+	dom := s.dom.matches(d, last)
+	dow := s.dow.matches(d, last)
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first time after after at which s fires, in the
+// location of s and with the layout of after. It returns the zero Toki
+// if s never fires.
+func (s *Schedule) Next(after toki.Toki) toki.Toki {
+	w := after.Time.In(s.loc)
+	d := toki.CivilDateOf(toki.Toki{Time: w})
+	h, m, sec := w.Hour(), w.Minute(), w.Second()+1
+	end := d.Year + horizon
+	for d.Year <= end {
+		if s.month&(1<<uint(d.Month)) == 0 {
+			d = withDay(d, daysInMonth(d)).AddDays(1)
+			h, m, sec = 0, 0, 0
+			continue
+		}
+		if s.dayMatches(d) {
+			for {
+				var ok bool
+				if h, m, sec, ok = s.nextClock(h, m, sec); !ok {
+					break
+				}
+				if t := wallTime(d, h, m, sec, s.loc); t.After(after.Time) {
+					after.Time = t
+					return after
+				}
+				sec++
+			}
+		}
+		d = d.AddDays(1)
+		h, m, sec = 0, 0, 0
+	}
+	return toki.Toki{}
+}
+
+// Prev returns the last time before before at which s fires, in the
+// location of s and with the layout of before. It returns the zero Toki
+// if s never fires.
+func (s *Schedule) Prev(before toki.Toki) toki.Toki {
+	w := before.Time.In(s.loc)
+	// In the second occurrence of a repeated interval, the first
+	// occurrences of later wall clock times are still before before.
+	if start, _ := w.ZoneBounds(); !start.IsZero() {
+		_, off := w.Zone()
+		_, prev := start.Add(-time.Nanosecond).Zone()
+		if gap := time.Duration(prev-off) * time.Second; gap > 0 && w.Before(start.Add(gap)) {
+			w = w.In(time.FixedZone("", prev))
+		}
+	}
+	d := toki.CivilDateOf(toki.Toki{Time: w})
+	h, m, sec := w.Hour(), w.Minute(), w.Second()
+	end := d.Year - horizon
+	for d.Year >= end {
+		if s.month&(1<<uint(d.Month)) == 0 {
+			d = withDay(d, 1).AddDays(-1)
+			h, m, sec = 23, 59, 59
+			continue
+		}
+		if s.dayMatches(d) {
+			for {
+				var ok bool
+				if h, m, sec, ok = s.prevClock(h, m, sec); !ok {
+					break
+				}
+				if t := wallTime(d, h, m, sec, s.loc); t.Before(before.Time) {
+					before.Time = t
+					return before
+				}
+				sec--
+			}
+		}
+		d = d.AddDays(-1)
+		h, m, sec = 23, 59, 59
+	}
+	return toki.Toki{}
+}
+
+// nextClock returns the first time of day of s at or after h:m:sec, whose
+// fields may overflow by one.
+func (s *Schedule) nextClock(h, m, sec int) (int, int, int, bool) {
+	if sec > 59 {
+		sec, m = 0, m+1
+	}
+	if m > 59 {
+		m, h = 0, h+1
+	}
+	for hh := nextBit(s.hour, h); hh >= 0; hh = nextBit(s.hour, hh+1) {
+		if hh != h {
+			m, sec = 0, 0
+		}
+		for mm := nextBit(s.minute, m); mm >= 0; mm = nextBit(s.minute, mm+1) {
+			if mm != m {
+				sec = 0
+			}
+			if ss := nextBit(s.second, sec); ss >= 0 {
+				return hh, mm, ss, true
+			}
+			sec = 0
+		}
+		m, sec = 0, 0
+	}
+	return 0, 0, 0, false
+}
+
+// prevClock returns the last time of day of s at or before h:m:sec,
+// whose fields may underflow by one.
+func (s *Schedule) prevClock(h, m, sec int) (int, int, int, bool) {
+	if sec < 0 {
+		sec, m = 59, m-1
+	}
+	if m < 0 {
+		m, h = 59, h-1
+	}
+	for hh := prevBit(s.hour, h); hh >= 0; hh = prevBit(s.hour, hh-1) {
+		if hh != h {
+			m, sec = 59, 59
+		}
+		for mm := prevBit(s.minute, m); mm >= 0; mm = prevBit(s.minute, mm-1) {
+			if mm != m {
+				sec = 59
+			}
+			if ss := prevBit(s.second, sec); ss >= 0 {
+				return hh, mm, ss, true
+			}
+			sec = 59
+		}
+		m, sec = 59, 59
+	}
+	return 0, 0, 0, false
+}
+
+// nextBit returns the lowest set bit of b at or above i, or -1.
+func nextBit(b uint64, i int) int {
+	if i < 0 || i > 63 {
+		return -1
+	}
+	b &^= 1<<uint(i) - 1
+	if b == 0 {
+		return -1
+	}
+	return bits.TrailingZeros64(b)
+}
+
+// prevBit returns the highest set bit of b at or below i, or -1.
+func prevBit(b uint64, i int) int {
+	if i < 0 {
+		return -1
+	}
+	if i < 63 {
+		b &= 1<<uint(i+1) - 1
+	}
+	return 63 - bits.LeadingZeros64(b)
+}
+
+func withDay(d toki.CivilDate, day int) toki.CivilDate {
+	d.Day = day
+	return d
+}
+
+func daysInMonth(d toki.CivilDate) int {
+	return toki.DaysIn(d.Month, d.Year)
+}
+
+// wallTime returns the first instant at which the wall clock of loc reads
+// the time h:m:sec of d, or the end of the transition that skips it.
+func wallTime(d toki.CivilDate, h, m, sec int, loc *time.Location) time.Time {
+	t := time.Date(d.Year, d.Month, d.Day, h, m, sec, 0, loc)
+	want := time.Date(d.Year, d.Month, d.Day, h, m, sec, 0, time.UTC)
+	got := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	switch {
+	case got.After(want):
+		// Skipped, and resolved with the offset before the transition.
+		start, _ := t.ZoneBounds()
+		return start
+	case got.Before(want):
+		// Skipped, and resolved with the offset after the transition.
+		_, end := t.ZoneBounds()
+		return end
+	}
+
+	// Repeated, if t is in the second occurrence.
+	if start, _ := t.ZoneBounds(); !start.IsZero() {
+		_, off := t.Zone()
+		_, prev := start.Add(-time.Nanosecond).Zone()
+		if first := t.Add(time.Duration(off-prev) * time.Second); prev > off && first.Before(start) {
+			return first
+		}
+	}
+	return t
+}
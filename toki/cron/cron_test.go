@@ -0,0 +1,213 @@
+package cron
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+var base = toki.Date(2023, time.October, 16, 10, 15, 0, 0, time.UTC)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestNext(t *testing.T) {
+	utc := func(year int, month time.Month, day, hour, min, sec int) toki.Toki {
+		return toki.Date(year, month, day, hour, min, sec, 0, time.UTC)
+	}
+
+	tests := [...]struct {
+		spec string
+		want toki.Toki
+	}{
+		0:  {"0 9 * * *", utc(2023, time.October, 17, 9, 0, 0)},
+		1:  {"*/15 * * * *", utc(2023, time.October, 16, 10, 30, 0)},
+		2:  {"30 * * * * *", utc(2023, time.October, 16, 10, 15, 30)},
+		3:  {"@monthly", utc(2023, time.November, 1, 0, 0, 0)},
+		4:  {"@weekly", utc(2023, time.October, 22, 0, 0, 0)},
+		5:  {"@yearly", utc(2024, time.January, 1, 0, 0, 0)},
+		6:  {"@hourly", utc(2023, time.October, 16, 11, 0, 0)},
+		7:  {"0 12 * * SUN-TUE", utc(2023, time.October, 16, 12, 0, 0)},
+		8:  {"0 0 * * 7", utc(2023, time.October, 22, 0, 0, 0)},
+		9:  {"0 0 1,15 jan,jul *", utc(2024, time.January, 1, 0, 0, 0)},
+		10: {"0 0 29 2 *", utc(2024, time.February, 29, 0, 0, 0)},
+		11: {"0 0 30 2 *", toki.Toki{}},
+		// Either day field when both are restricted: the 13th or a Friday.
+		12: {"0 0 13 * 5", utc(2023, time.October, 20, 0, 0, 0)},
+		// Both when one starts with *: days 1, 11, 21 and 31 that are Fridays.
+		13: {"0 0 */10 * 5", utc(2023, time.December, 1, 0, 0, 0)},
+		14: {"0 0 ? * 5", utc(2023, time.October, 20, 0, 0, 0)},
+		// Extensions.
+		15: {"0 0 L * *", utc(2023, time.October, 31, 0, 0, 0)},
+		16: {"0 0 L-1 2 *", utc(2024, time.February, 28, 0, 0, 0)},
+		// October 15, 2023 is a Sunday and June 1, 2024 a Saturday.
+		17: {"0 0 15W 10 *", utc(2024, time.October, 15, 0, 0, 0)},
+		18: {"0 0 1W 6 *", utc(2024, time.June, 3, 0, 0, 0)},
+		// March 31, 2024 is a Sunday.
+		19: {"0 0 LW 3 *", utc(2024, time.March, 29, 0, 0, 0)},
+		20: {"0 0 * * 5L", utc(2023, time.October, 27, 0, 0, 0)},
+		21: {"0 0 * * MON#3", utc(2023, time.November, 20, 0, 0, 0)},
+		22: {"0 0 * 2 1#5", utc(2044, time.February, 29, 0, 0, 0)},
+	}
+
+	for i, tt := range tests {
+		s, err := ParseInLocation(tt.spec, time.UTC)
+		if err != nil {
+			t.Errorf("#%d:: ParseInLocation(%q) error = %v", i, tt.spec, err)
+			continue
+		}
+		got := s.Next(base)
+		if !got.Equal(tt.want) {
+			t.Errorf("#%d:: %q.Next(%v) = %v, want %v", i, tt.spec, base, got, tt.want)
+		}
+	}
+}
+
+func TestPrev(t *testing.T) {
+	tests := [...]struct {
+		spec   string
+		before toki.Toki
+		want   toki.Toki
+	}{
+		0: {"0 9 * * *", base, toki.Date(2023, time.October, 16, 9, 0, 0, 0, time.UTC)},
+		1: {"0 9 * * *", toki.Date(2023, time.October, 16, 9, 0, 0, 0, time.UTC), toki.Date(2023, time.October, 15, 9, 0, 0, 0, time.UTC)},
+		2: {"0 9 * * *", toki.Date(2023, time.October, 16, 9, 0, 0, 1, time.UTC), toki.Date(2023, time.October, 16, 9, 0, 0, 0, time.UTC)},
+		3: {"0 0 L * *", base, toki.Date(2023, time.September, 30, 0, 0, 0, 0, time.UTC)},
+		4: {"0 0 * * MON#3", base, toki.Date(2023, time.October, 16, 0, 0, 0, 0, time.UTC)},
+		5: {"0 0 29 2 *", base, toki.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		6: {"0 0 30 2 *", base, toki.Toki{}},
+	}
+
+	for i, tt := range tests {
+		s, err := ParseInLocation(tt.spec, time.UTC)
+		if err != nil {
+			t.Errorf("#%d:: ParseInLocation(%q) error = %v", i, tt.spec, err)
+			continue
+		}
+		got := s.Prev(tt.before)
+		if !got.Equal(tt.want) {
+			t.Errorf("#%d:: %q.Prev(%v) = %v, want %v", i, tt.spec, tt.before, got, tt.want)
+		}
+	}
+}
+
+func TestDST(t *testing.T) {
+	la := loadLocation(t, "America/Los_Angeles")
+	at := func(month time.Month, day, hour, min int, zone string) toki.Toki {
+		offset := -8 * 60 * 60
+		if zone == "PDT" {
+			offset = -7 * 60 * 60
+		}
+		return toki.Date(2023, month, day, hour, min, 0, 0, time.FixedZone(zone, offset))
+	}
+
+	tests := [...]struct {
+		spec string
+		from toki.Toki
+		next toki.Toki
+	}{
+		// 02:30 is skipped on March 12 and fires at 03:00.
+		0: {"30 2 * * *", at(time.March, 12, 0, 0, "PST"), at(time.March, 12, 3, 0, "PDT")},
+		1: {"30 2 * * *", at(time.March, 12, 3, 0, "PDT"), at(time.March, 13, 2, 30, "PDT")},
+		2: {"*/15 * * * *", at(time.March, 12, 1, 45, "PST"), at(time.March, 12, 3, 0, "PDT")},
+		3: {"*/15 * * * *", at(time.March, 12, 3, 0, "PDT"), at(time.March, 12, 3, 15, "PDT")},
+		// 01:30 is repeated on November 5 and fires once.
+		4: {"30 1 * * *", at(time.November, 5, 0, 0, "PDT"), at(time.November, 5, 1, 30, "PDT")},
+		5: {"30 1 * * *", at(time.November, 5, 1, 30, "PDT"), at(time.November, 6, 1, 30, "PST")},
+		6: {"*/30 * * * *", at(time.November, 5, 1, 30, "PDT"), at(time.November, 5, 2, 0, "PST")},
+		7: {"*/30 * * * *", at(time.November, 5, 1, 10, "PST"), at(time.November, 5, 2, 0, "PST")},
+	}
+
+	for i, tt := range tests {
+		s, err := ParseInLocation(tt.spec, la)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := s.Next(tt.from)
+		if !got.Equal(tt.next) {
+			t.Errorf("#%d:: %q.Next(%v) = %v, want %v", i, tt.spec, tt.from, got, tt.next)
+		}
+		if got.Location() != la {
+			t.Errorf("#%d:: %q.Next(%v) in %v, want %v", i, tt.spec, tt.from, got.Location(), la)
+		}
+	}
+
+	// The first occurrence of 01:30 is before the second one.
+	s, _ := ParseInLocation("30 1 * * *", la)
+	if got, want := s.Prev(at(time.November, 5, 1, 10, "PST")), at(time.November, 5, 1, 30, "PDT"); !got.Equal(want) {
+		t.Errorf("Prev() in the repeated hour = %v, want %v", got, want)
+	}
+	s, _ = ParseInLocation("30 2 * * *", la)
+	if got, want := s.Prev(at(time.March, 12, 4, 0, "PDT")), at(time.March, 12, 3, 0, "PDT"); !got.Equal(want) {
+		t.Errorf("Prev() after the skipped hour = %v, want %v", got, want)
+	}
+}
+
+func TestNextPrevConsistency(t *testing.T) {
+	la := loadLocation(t, "America/Los_Angeles")
+	specs := []string{
+		"*/7 * * * *",
+		"0 */5 * * * *",
+		"30 1,2 * * *",
+		"0 0 L,15W * *",
+		"0 12 * * 1#2,5L",
+		"@daily",
+	}
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2023, time.March, 1, 0, 0, 0, 0, la).Unix()
+	end := time.Date(2023, time.December, 1, 0, 0, 0, 0, la).Unix()
+
+	for _, spec := range specs {
+		s, err := ParseInLocation(spec, la)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 200; i++ {
+			from := toki.Unix(start+rnd.Int63n(end-start), int64(rnd.Intn(2))*500000000)
+			next := s.Next(from)
+			if !next.After(from) {
+				t.Errorf("%q.Next(%v) = %v, not after", spec, from, next)
+				continue
+			}
+			if prev := s.Prev(next); !prev.Before(next) || !prev.Before(from.Add(time.Nanosecond)) && !prev.Equal(from) {
+				t.Errorf("%q.Prev(%v) = %v, want at or before %v", spec, next, prev, from)
+			}
+			if got := s.Prev(next.Add(time.Nanosecond)); !got.Equal(next) {
+				t.Errorf("%q.Prev(%v) = %v, want %v", spec, next.Add(time.Nanosecond), got, next)
+			}
+			if got := s.Next(s.Prev(next)); !got.Equal(next) {
+				t.Errorf("%q.Next(Prev(%v)) = %v", spec, next, got)
+			}
+		}
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	s, err := ParseInLocation("CRON_TZ=Asia/Tokyo 0 9 * * *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Location().String() != tokyo.String() {
+		t.Errorf("Location() = %v, want %v", s.Location(), tokyo)
+	}
+	want := toki.Date(2023, time.October, 17, 9, 0, 0, 0, tokyo)
+	if got := s.Next(base); !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", base, got, want)
+	}
+
+	la := loadLocation(t, "America/Los_Angeles")
+	restore := toki.SetDefaultLocation(la)
+	defer restore()
+	if s := MustParse("@daily"); s.Location() != la {
+		t.Errorf("Parse() location = %v, want %v", s.Location(), la)
+	}
+}
@@ -0,0 +1,283 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+// descriptors are the predefined schedules, in the six-field form.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var weekdayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// Parse parses a cron expression evaluated in toki.DefaultLocation. See
+// ParseInLocation for the syntax.
+func Parse(spec string) (*Schedule, error) {
+	return ParseInLocation(spec, toki.DefaultLocation())
+}
+
+// ParseInLocation parses a cron expression evaluated in loc. The
+// expression has five fields, minute, hour, day of month, month and day
+// of week, or six with a leading second field, or is one of the
+// descriptors @yearly (or @annually), @monthly, @weekly, @daily (or
+// @midnight) and @hourly. A CRON_TZ= or TZ= prefix, as in
+// "CRON_TZ=Asia/Tokyo 0 9 * * *", overrides loc.
+//
+// Each field is a comma-separated list of *, a value, a range a-b, or
+// either of the last two followed by /step. Months and days of week may
+// be written as JAN-DEC and SUN-SAT, and Sunday as 0 or 7. ? is the same
+// as * in the day fields. The day of month also accepts L for the last
+// day, L-n for n days before it, nW for the weekday nearest to day n
+// within the month, and LW for the last weekday; the day of week accepts
+// nL for the last day n of the month, and n#k for its kth occurrence.
+//
+// As in Vixie cron, a day matches either day field when both are
+// restricted, and both of them when either starts with * or ?.
+func ParseInLocation(spec string, loc *time.Location) (*Schedule, error) {
+	s := &Schedule{spec: spec, loc: loc}
+	expr := strings.TrimSpace(spec)
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if strings.HasPrefix(expr, prefix) {
+			name, rest, _ := strings.Cut(expr[len(prefix):], " ")
+			l, err := time.LoadLocation(name)
+			if err != nil {
+				return nil, fmt.Errorf("cron: %q: %w", spec, err)
+			}
+			s.loc, expr = l, strings.TrimSpace(rest)
+			break
+		}
+	}
+	if s.loc == nil {
+		s.loc = time.UTC
+	}
+
+	if strings.HasPrefix(expr, "@") {
+		d, ok := descriptors[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("cron: unknown descriptor %q", expr)
+		}
+		expr = d
+	}
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron: %q has %d fields, want 5 or 6", spec, len(fields))
+	}
+
+	var err error
+	wrap := func(name string, e error) error {
+		return fmt.Errorf("cron: %q: %s: %w", spec, name, e)
+	}
+	if s.second, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, wrap("second", err)
+	}
+	if s.minute, err = parseField(fields[1], 0, 59, nil); err != nil {
+		return nil, wrap("minute", err)
+	}
+	if s.hour, err = parseField(fields[2], 0, 23, nil); err != nil {
+		return nil, wrap("hour", err)
+	}
+	if s.dom, err = parseDayOfMonth(fields[3]); err != nil {
+		return nil, wrap("day of month", err)
+	}
+	if s.month, err = parseField(fields[4], 1, 12, monthNames); err != nil {
+		return nil, wrap("month", err)
+	}
+	if s.dow, err = parseDayOfWeek(fields[5]); err != nil {
+		return nil, wrap("day of week", err)
+	}
+	s.domStar = isStar(fields[3])
+	s.dowStar = isStar(fields[5])
+	return s, nil
+}
+
+// MustParse is like Parse but panics if the expression cannot be parsed.
+func MustParse(spec string) *Schedule {
+	s, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func isStar(field string) bool {
+	return strings.HasPrefix(field, "*") || field == "?"
+}
+
+// parseField parses a list of values, ranges and steps between min and
+// max into a bit set.
+func parseField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		b, err := parseItem(item, min, max, names)
+		if err != nil {
+			return 0, err
+		}
+		bits |= b
+	}
+	return bits, nil
+}
+
+// parseItem parses *, a, a-b, */n, a/n or a-b/n.
+func parseItem(item string, min, max int, names map[string]int) (uint64, error) {
+	rng, stepText, hasStep := strings.Cut(item, "/")
+	step := 1
+	if hasStep {
+		n, err := strconv.Atoi(stepText)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid step in %q", item)
+		}
+		step = n
+	}
+
+	lo, hi := min, max
+	switch {
+	case rng == "*":
+	case rng == "":
+		return 0, fmt.Errorf("empty item in %q", item)
+	default:
+		a, b, isRange := strings.Cut(rng, "-")
+		var err error
+		if lo, err = parseValue(a, min, max, names); err != nil {
+			return 0, err
+		}
+		switch {
+		case isRange:
+			if hi, err = parseValue(b, min, max, names); err != nil {
+				return 0, err
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("range %q ends before it starts", rng)
+			}
+		case !hasStep:
+			hi = lo
+		}
+	}
+
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
+
+func parseValue(s string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, min, max)
+	}
+	return v, nil
+}
+
+// parseDayOfMonth parses the day of month field with its L and W
+// extensions.
+func parseDayOfMonth(field string) (dayOfMonth, error) {
+	var f dayOfMonth
+	for _, item := range strings.Split(field, ",") {
+		switch {
+		case item == "?":
+			f.bits |= allBits(1, 31)
+		case strings.EqualFold(item, "LW"):
+			f.lastWeekday = true
+		case strings.EqualFold(item, "L"):
+			f.fromLast = append(f.fromLast, 0)
+		case len(item) > 2 && (item[0] == 'L' || item[0] == 'l') && item[1] == '-':
+			n, err := strconv.Atoi(item[2:])
+			if err != nil || n < 0 || n > 30 {
+				return dayOfMonth{}, fmt.Errorf("invalid offset in %q", item)
+			}
+			f.fromLast = append(f.fromLast, n)
+		case len(item) > 1 && (item[len(item)-1] == 'W' || item[len(item)-1] == 'w'):
+			n, err := parseValue(item[:len(item)-1], 1, 31, nil)
+			if err != nil {
+				return dayOfMonth{}, err
+			}
+			f.nearestWeekday = append(f.nearestWeekday, n)
+		default:
+			b, err := parseItem(item, 1, 31, nil)
+			if err != nil {
+				return dayOfMonth{}, err
+			}
+			f.bits |= b
+		}
+	}
+	return f, nil
+}
+
+// parseDayOfWeek parses the day of week field with its L and #
+// extensions.
+func parseDayOfWeek(field string) (dayOfWeek, error) {
+	var f dayOfWeek
+	for _, item := range strings.Split(field, ",") {
+		switch {
+		case item == "?":
+			f.bits |= allBits(0, 6)
+		case len(item) > 1 && (item[len(item)-1] == 'L' || item[len(item)-1] == 'l'):
+			wd, err := parseWeekday(item[:len(item)-1])
+			if err != nil {
+				return dayOfWeek{}, err
+			}
+			f.last |= 1 << uint(wd)
+		case strings.Contains(item, "#"):
+			a, b, _ := strings.Cut(item, "#")
+			wd, err := parseWeekday(a)
+			if err != nil {
+				return dayOfWeek{}, err
+			}
+			k, err := strconv.Atoi(b)
+			if err != nil || k < 1 || k > 5 {
+				return dayOfWeek{}, fmt.Errorf("invalid occurrence in %q", item)
+			}
+			f.nth[wd] |= 1 << uint(k)
+		default:
+			b, err := parseItem(item, 0, 7, weekdayNames)
+			if err != nil {
+				return dayOfWeek{}, err
+			}
+			f.bits |= b
+		}
+	}
+	// Sunday may be written as 7.
+	if f.bits&(1<<7) != 0 {
+		f.bits = f.bits&^(1<<7) | 1
+	}
+	return f, nil
+}
+
+func parseWeekday(s string) (int, error) {
+	wd, err := parseValue(s, 0, 7, weekdayNames)
+	return wd % 7, err
+}
+
+// allBits returns the bit set of the values from lo to hi.
+func allBits(lo, hi int) uint64 {
+	return (1<<uint(hi-lo+1) - 1) << uint(lo)
+}
@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseField(t *testing.T) {
+	tests := [...]struct {
+		field    string
+		min, max int
+		want     uint64
+	}{
+		0: {"*", 0, 5, 0x3f},
+		1: {"3", 0, 59, 1 << 3},
+		2: {"1-3,5", 0, 59, 1<<1 | 1<<2 | 1<<3 | 1<<5},
+		3: {"*/20", 0, 59, 1 | 1<<20 | 1<<40},
+		4: {"10/20", 0, 59, 1<<10 | 1<<30 | 1<<50},
+		5: {"2-8/3", 0, 59, 1<<2 | 1<<5 | 1<<8},
+		6: {"feb-apr", 1, 12, 1<<2 | 1<<3 | 1<<4},
+	}
+
+	for i, tt := range tests {
+		got, err := parseField(tt.field, tt.min, tt.max, monthNames)
+		if err != nil {
+			t.Errorf("#%d:: parseField(%q) error = %v", i, tt.field, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("#%d:: parseField(%q) = %#x, want %#x", i, tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"1,,2 * * * *",
+		"a * * * *",
+		"@every 1h",
+		"* * L-31 * *",
+		"* * 32W * *",
+		"* * * * MON#6",
+		"* * * * 8L",
+		"CRON_TZ=Nowhere/City * * * * *",
+	}
+
+	for i, spec := range specs {
+		if _, err := ParseInLocation(spec, time.UTC); err == nil {
+			t.Errorf("#%d:: ParseInLocation(%q) succeeded, want error", i, spec)
+		}
+	}
+}
+
+func TestParseDayOfWeek(t *testing.T) {
+	f, err := parseDayOfWeek("5-7,MON#2,fri#5,3L")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := uint64(1<<5 | 1<<6 | 1); f.bits != want {
+		t.Errorf("bits = %#x, want %#x", f.bits, want)
+	}
+	if f.nth[time.Monday] != 1<<2 || f.nth[time.Friday] != 1<<5 {
+		t.Errorf("nth = %v, want the 2nd Monday and 5th Friday", f.nth)
+	}
+	if f.last != 1<<3 {
+		t.Errorf("last = %#x, want %#x", f.last, 1<<3)
+	}
+}
+
+func TestMustParsePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParse of an invalid expression did not panic")
+		}
+	}()
+	MustParse("* * *")
+}
@@ -0,0 +1,443 @@
+package toki
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts of Duration.
+const (
+	// LayoutDuration marshals a Duration as a string such as "2d3h30m",
+	// parsed by ParseDuration.
+	LayoutDuration = "duration"
+	// LayoutDurationSeconds marshals a Duration as a number of seconds,
+	// with a fraction if needed.
+	LayoutDurationSeconds = "duration_seconds"
+	// LayoutDurationMilli marshals a Duration as a number of milliseconds,
+	// with a fraction if needed.
+	LayoutDurationMilli = "duration_milli"
+)
+
+// A Duration is a time.Duration that marshals to JSON and text as set by
+// its layout, LayoutDuration by default.
+type Duration struct {
+	layout string
+	time.Duration
+}
+
+// NewDuration returns d as a Duration marshalled in the first of layouts.
+func NewDuration(d time.Duration, layouts ...string) Duration {
+	return Duration{layout: setDurationLayout(layouts...), Duration: d}
+}
+
+func setDurationLayout(layouts ...string) string {
+	if len(layouts) > 0 {
+		return layouts[0]
+	}
+	return LayoutDuration
+}
+
+func (d Duration) GetLayout() string {
+	if d.layout == "" {
+		return LayoutDuration
+	}
+	return d.layout
+}
+
+// String returns d formatted by FormatDuration.
+func (d Duration) String() string {
+	return FormatDuration(d.Duration)
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if d.GetLayout() == LayoutDuration {
+		return json.Marshal(FormatDuration(d.Duration))
+	}
+	return d.MarshalText()
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	switch d.GetLayout() {
+	case LayoutDuration:
+		return []byte(FormatDuration(d.Duration)), nil
+	case LayoutDurationSeconds:
+		return []byte(formatDurationIn(d.Duration, time.Second)), nil
+	case LayoutDurationMilli:
+		return []byte(formatDurationIn(d.Duration, time.Millisecond)), nil
+	}
+	return nil, fmt.Errorf("Duration.MarshalText: unknown layout %q", d.layout)
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if d.GetLayout() == LayoutDuration {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("Duration.UnmarshalJSON: %w", err)
+		}
+		data = []byte(s)
+	}
+	return d.UnmarshalText(data)
+}
+
+func (d *Duration) UnmarshalText(data []byte) error {
+	var err error
+	switch d.GetLayout() {
+	case LayoutDuration:
+		d.Duration, err = ParseDuration(string(data))
+	case LayoutDurationSeconds:
+		d.Duration, err = parseDurationIn(data, time.Second)
+	case LayoutDurationMilli:
+		d.Duration, err = parseDurationIn(data, time.Millisecond)
+	default:
+		err = fmt.Errorf("Duration.UnmarshalText: unknown layout %q", d.layout)
+	}
+	return err
+}
+
+// formatDurationIn formats d as a decimal number of unit.
+func formatDurationIn(d, unit time.Duration) string {
+	s := strconv.FormatInt(int64(d/unit), 10)
+	if r := d % unit; r != 0 {
+		if r < 0 {
+			r = -r
+			if d > -unit {
+				s = "-" + s
+			}
+		}
+		digits := len(strconv.FormatInt(int64(unit), 10)) - 1
+		s += "." + strings.TrimRight(fmt.Sprintf("%0*d", digits, int64(r)), "0")
+	}
+	return s
+}
+
+// parseDurationIn parses a decimal number of unit.
+func parseDurationIn(data []byte, unit time.Duration) (time.Duration, error) {
+	s := string(bytes.TrimSpace(data))
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit) {
+			return 0, fmt.Errorf("toki: duration %q out of range", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("toki: invalid duration %q", s)
+	}
+	v := math.Round(f * float64(unit))
+	if v >= math.MaxInt64 || v < math.MinInt64 {
+		return 0, fmt.Errorf("toki: duration %q out of range", s)
+	}
+	return time.Duration(v), nil
+}
+
+// FormatDuration formats d like time.Duration.String, but with whole
+// days, and without zero trailing units: 49h30m is "2d1h30m" and 48h is
+// "2d". ParseDuration parses the result.
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	sign := ""
+	u := uint64(d)
+	if d < 0 {
+		sign, u = "-", -u
+	}
+	days := u / uint64(oneDay)
+	rest := time.Duration(u % uint64(oneDay))
+
+	var s string
+	if days > 0 {
+		s = strconv.FormatUint(days, 10) + "d"
+	}
+	if rest != 0 {
+		r := rest.String()
+		if strings.HasSuffix(r, "m0s") {
+			r = strings.TrimSuffix(r, "0s")
+		}
+		if strings.HasSuffix(r, "h0m") {
+			r = strings.TrimSuffix(r, "0m")
+		}
+		s += r
+	}
+	return sign + s
+}
+
+// durationUnits are the lengths of the units accepted by ParseDuration.
+var durationUnits = map[string]uint64{
+	"ns": uint64(time.Nanosecond),
+	"us": uint64(time.Microsecond),
+	"µs": uint64(time.Microsecond), // U+00B5 micro sign
+	"μs": uint64(time.Microsecond), // U+03BC Greek letter mu
+	"ms": uint64(time.Millisecond),
+	"s":  uint64(time.Second),
+	"m":  uint64(time.Minute),
+	"h":  uint64(time.Hour),
+	"d":  uint64(oneDay),
+	"w":  uint64(7 * oneDay),
+}
+
+// A durationComponent is a decimal number followed by a unit, such as
+// 1.5h.
+type durationComponent struct {
+	whole uint64
+	frac  uint64  // fractional digits
+	scale float64 // 10 to the number of fractional digits
+	unit  string
+}
+
+var errDurationOverflow = errors.New("overflow")
+
+// scanDuration splits s, a possibly signed sequence of decimal numbers
+// each followed by a unit, into its components.
+func scanDuration(s string) (neg bool, comps []durationComponent, ok bool) {
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "" {
+		return false, nil, false
+	}
+	for s != "" {
+		var c durationComponent
+		c.scale = 1
+		i := 0
+		for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+			if c.whole > (math.MaxUint64-9)/10 {
+				return false, nil, false
+			}
+			c.whole = c.whole*10 + uint64(s[i]-'0')
+		}
+		pre := i > 0
+		post := false
+		if i < len(s) && s[i] == '.' {
+			i++
+			for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+				post = true
+				if c.scale < 1e18 {
+					c.frac = c.frac*10 + uint64(s[i]-'0')
+					c.scale *= 10
+				}
+			}
+		}
+		if !pre && !post {
+			return false, nil, false
+		}
+		j := i
+		for ; j < len(s) && s[j] != '.' && (s[j] < '0' || s[j] > '9'); j++ {
+		}
+		c.unit = s[i:j]
+		if c.unit == "" {
+			return false, nil, false
+		}
+		s = s[j:]
+		comps = append(comps, c)
+	}
+	return neg, comps, true
+}
+
+// nanoseconds returns the length of c in nanoseconds, given the length
+// of its unit.
+func (c durationComponent) nanoseconds(unit uint64) (uint64, error) {
+	if c.whole > (1<<63)/unit {
+		return 0, errDurationOverflow
+	}
+	v := c.whole * unit
+	if c.frac > 0 {
+		v += uint64(float64(c.frac) * (float64(unit) / c.scale))
+		if v > 1<<63 {
+			return 0, errDurationOverflow
+		}
+	}
+	return v, nil
+}
+
+// ParseDuration parses a duration string like time.ParseDuration, with
+// the additional units "d" for 24 hours and "w" for 7 days, as in "1w2d"
+// or "1.5d". Use ParsePeriod for months and years, whose length varies.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "0" || s == "+0" || s == "-0" {
+		return 0, nil
+	}
+	neg, comps, ok := scanDuration(s)
+	if !ok {
+		return 0, fmt.Errorf("toki: invalid duration %q", s)
+	}
+	var total uint64
+	for _, c := range comps {
+		unit, ok := durationUnits[c.unit]
+		if !ok {
+			if c.unit == "mo" || c.unit == "y" {
+				return 0, fmt.Errorf("toki: unit %q in duration %q has no fixed length; use ParsePeriod", c.unit, s)
+			}
+			return 0, fmt.Errorf("toki: unknown unit %q in duration %q", c.unit, s)
+		}
+		v, err := c.nanoseconds(unit)
+		if err != nil || total+v > 1<<63 {
+			return 0, fmt.Errorf("toki: invalid duration %q", s)
+		}
+		total += v
+	}
+	if neg {
+		return -time.Duration(total), nil
+	}
+	if total > 1<<63-1 {
+		return 0, fmt.Errorf("toki: invalid duration %q", s)
+	}
+	return time.Duration(total), nil
+}
+
+// ParsePeriod parses a Period written with the units of ParseDuration
+// and "mo" for months and "y" for years, as in "1y2mo3d4h", or in the
+// ISO 8601 format of Period.String, as in "P1Y2M3DT4H". Years, months,
+// weeks and days must be whole numbers; weeks are counted as 7 days. A
+// leading sign applies to every field. In the ISO 8601 format, each field
+// may also have its own sign, as in "P1M-2D".
+func ParsePeriod(s string) (Period, error) {
+	if s == "0" || s == "+0" || s == "-0" {
+		return Period{}, nil
+	}
+	if t := strings.TrimLeft(s, "+-"); strings.HasPrefix(t, "P") && len(s)-len(t) <= 1 {
+		return parseISOPeriod(s)
+	}
+	neg, comps, ok := scanDuration(s)
+	if !ok {
+		return Period{}, fmt.Errorf("toki: invalid period %q", s)
+	}
+
+	var p Period
+	var clock uint64
+	for _, c := range comps {
+		switch c.unit {
+		case "y", "mo", "w", "d":
+			if c.frac != 0 || c.whole > math.MaxInt32 {
+				return Period{}, fmt.Errorf("toki: invalid %q component in period %q", c.unit, s)
+			}
+			n := int(c.whole)
+			switch c.unit {
+			case "y":
+				p.Years += n
+			case "mo":
+				p.Months += n
+			case "w":
+				p.Days += 7 * n
+			case "d":
+				p.Days += n
+			}
+			continue
+		}
+		unit, ok := durationUnits[c.unit]
+		if !ok {
+			return Period{}, fmt.Errorf("toki: unknown unit %q in period %q", c.unit, s)
+		}
+		v, err := c.nanoseconds(unit)
+		if err != nil || clock+v > 1<<63-1 {
+			return Period{}, fmt.Errorf("toki: invalid period %q", s)
+		}
+		clock += v
+	}
+	p.setClock(time.Duration(clock))
+	if neg {
+		p = p.Negate()
+	}
+	return p, nil
+}
+
+// setClock sets the clock fields of p from d.
+func (p *Period) setClock(d time.Duration) {
+	p.Hours = int(d / time.Hour)
+	p.Minutes = int(d % time.Hour / time.Minute)
+	p.Seconds = int(d % time.Minute / time.Second)
+	p.Nanoseconds = int(d % time.Second)
+}
+
+// parseISOPeriod parses the ISO 8601 format PnYnMnWnDTnHnMnS, with an
+// optional sign on the whole period and on each field, as in P1M-2D, and
+// a fraction on the seconds only.
+func parseISOPeriod(s string) (Period, error) {
+	bad := func() (Period, error) {
+		return Period{}, fmt.Errorf("toki: invalid period %q", s)
+	}
+	t := s
+	neg := false
+	if t[0] == '-' || t[0] == '+' {
+		neg = t[0] == '-'
+		t = t[1:]
+	}
+	t = t[1:] // P
+	if t == "" {
+		return bad()
+	}
+
+	var p Period
+	inTime := false
+	order := "YMWD"
+	for t != "" {
+		if t[0] == 'T' {
+			if inTime || len(t) == 1 {
+				return bad()
+			}
+			inTime, order, t = true, "HMS", t[1:]
+			continue
+		}
+		sign := ""
+		if t[0] == '-' || t[0] == '+' {
+			sign, t = t[:1], t[1:]
+		}
+		i := 0
+		for i < len(t) && ('0' <= t[i] && t[i] <= '9' || t[i] == '.' || t[i] == ',') {
+			i++
+		}
+		if i == 0 || i == len(t) {
+			return bad()
+		}
+		num, unit := sign+t[:i], t[i]
+		t = t[i+1:]
+
+		k := strings.IndexByte(order, unit)
+		if k < 0 {
+			return bad()
+		}
+		order = order[k+1:]
+
+		if inTime && unit == 'S' {
+			d, err := ParseDuration(strings.Replace(num, ",", ".", 1) + "s")
+			if err != nil {
+				return bad()
+			}
+			p.Seconds = int(d / time.Second)
+			p.Nanoseconds = int(d % time.Second)
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return bad()
+		}
+		switch {
+		case !inTime && unit == 'Y':
+			p.Years = n
+		case !inTime && unit == 'M':
+			p.Months = n
+		case unit == 'W':
+			p.Days += 7 * n
+		case unit == 'D':
+			p.Days += n
+		case unit == 'H':
+			p.Hours = n
+		case inTime && unit == 'M':
+			p.Minutes = n
+		}
+	}
+	if neg {
+		p = p.Negate()
+	}
+	return p, nil
+}
@@ -0,0 +1,226 @@
+package toki
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := [...]struct {
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		0:  {"0", 0, false},
+		1:  {"1h30m", 90 * time.Minute, false},
+		2:  {"2d", 48 * time.Hour, false},
+		3:  {"1w", 7 * 24 * time.Hour, false},
+		4:  {"1w2d3h", 9*24*time.Hour + 3*time.Hour, false},
+		5:  {"1.5d", 36 * time.Hour, false},
+		6:  {"-2d12h", -60 * time.Hour, false},
+		7:  {"+.5s", 500 * time.Millisecond, false},
+		8:  {"1µs2ns", 1002 * time.Nanosecond, false},
+		9:  {"2562047h47m16.854775807s", 1<<63 - 1, false},
+		10: {"-2562047h47m16.854775808s", -1 << 63, false},
+		11: {"106752d", 0, true},
+		12: {"1mo", 0, true},
+		13: {"1y", 0, true},
+		14: {"2", 0, true},
+		15: {"d", 0, true},
+		16: {"", 0, true},
+		17: {"1x", 0, true},
+		18: {"1..5h", 0, true},
+	}
+
+	for i, tt := range tests {
+		got, err := ParseDuration(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("#%d:: ParseDuration(%q) error = %v, wantErr %v", i, tt.s, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("#%d:: ParseDuration(%q) = %v, want %v", i, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	tests := [...]struct {
+		s       string
+		want    Period
+		wantErr bool
+	}{
+		0:  {"0", Period{}, false},
+		1:  {"1y2mo3d4h", Period{Years: 1, Months: 2, Days: 3, Hours: 4}, false},
+		2:  {"2w1d", Period{Days: 15}, false},
+		3:  {"1mo1m", Period{Months: 1, Minutes: 1}, false},
+		4:  {"90m", Period{Hours: 1, Minutes: 30}, false},
+		5:  {"36h", Period{Hours: 36}, false},
+		6:  {"1.5s", Period{Seconds: 1, Nanoseconds: 500000000}, false},
+		7:  {"-1y6mo", Period{Years: -1, Months: -6}, false},
+		8:  {"P1Y2M3DT4H5M6S", Period{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}, false},
+		9:  {"PT1.5S", Period{Seconds: 1, Nanoseconds: 500000000}, false},
+		10: {"P2W", Period{Days: 14}, false},
+		11: {"-P3M2D", Period{Months: -3, Days: -2}, false},
+		12: {"PT0S", Period{}, false},
+		13: {"1.5d", Period{}, true},
+		14: {"1x", Period{}, true},
+		15: {"P", Period{}, true},
+		16: {"P1D2Y", Period{}, true},
+		17: {"P1DT", Period{}, true},
+		18: {"P1H", Period{}, true},
+		// Signs on fields.
+		19: {"P1M-2D", Period{Months: 1, Days: -2}, false},
+		20: {"-P1M-2DT+3H", Period{Months: -1, Days: 2, Hours: -3}, false},
+		21: {"PT-1.5S", Period{Seconds: -1, Nanoseconds: -500000000}, false},
+		22: {"P-1W", Period{Days: -7}, false},
+		23: {"P--1D", Period{}, true},
+		24: {"P-T1H", Period{}, true},
+		25: {"P1-D", Period{}, true},
+	}
+
+	for i, tt := range tests {
+		got, err := ParsePeriod(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("#%d:: ParsePeriod(%q) error = %v, wantErr %v", i, tt.s, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("#%d:: ParsePeriod(%q) = %#v, want %#v", i, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParsePeriodString(t *testing.T) {
+	periods := []Period{
+		{},
+		{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 7},
+		{Months: -3, Days: -2},
+		{Seconds: 1, Nanoseconds: 500000000},
+		{Months: 1, Days: -2},
+		{Years: -1, Months: 2, Hours: 3, Minutes: -4},
+		{Days: 1, Seconds: -1, Nanoseconds: -500000000},
+	}
+	for i, p := range periods {
+		got, err := ParsePeriod(p.String())
+		if err != nil || got != p {
+			t.Errorf("#%d:: ParsePeriod(%q) = %#v, %v, want %#v", i, p.String(), got, err, p)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := [...]struct {
+		d    time.Duration
+		want string
+	}{
+		0: {0, "0s"},
+		1: {48 * time.Hour, "2d"},
+		2: {49*time.Hour + 30*time.Minute, "2d1h30m"},
+		3: {time.Hour, "1h"},
+		4: {90 * time.Second, "1m30s"},
+		5: {1500 * time.Millisecond, "1.5s"},
+		6: {-36 * time.Hour, "-1d12h"},
+		7: {24*time.Hour + time.Millisecond, "1d1ms"},
+		8: {-1 << 63, "-106751d23h47m16.854775808s"},
+	}
+
+	for i, tt := range tests {
+		got := FormatDuration(tt.d)
+		if got != tt.want {
+			t.Errorf("#%d:: FormatDuration(%v) = %q, want %q", i, tt.d, got, tt.want)
+		}
+		if d, err := ParseDuration(got); err != nil || d != tt.d {
+			t.Errorf("#%d:: ParseDuration(%q) = %v, %v, want %v", i, got, d, err, tt.d)
+		}
+	}
+}
+
+func TestDurationMarshalJSON(t *testing.T) {
+	tests := [...]struct {
+		d    Duration
+		want string
+	}{
+		0: {Duration{Duration: 49 * time.Hour}, `"2d1h"`},
+		1: {NewDuration(90*time.Minute, LayoutDuration), `"1h30m"`},
+		2: {NewDuration(90*time.Minute, LayoutDurationSeconds), `5400`},
+		3: {NewDuration(1500*time.Millisecond, LayoutDurationSeconds), `1.5`},
+		4: {NewDuration(-500*time.Millisecond, LayoutDurationSeconds), `-0.5`},
+		5: {NewDuration(1500*time.Millisecond, LayoutDurationMilli), `1500`},
+		6: {NewDuration(1500*time.Microsecond, LayoutDurationMilli), `1.5`},
+		7: {NewDuration(-time.Nanosecond, LayoutDurationMilli), `-0.000001`},
+	}
+
+	for i, tt := range tests {
+		b, err := json.Marshal(tt.d)
+		if err != nil {
+			t.Errorf("#%d:: json.Marshal(%v) error = %v", i, tt.d, err)
+			continue
+		}
+		if string(b) != tt.want {
+			t.Errorf("#%d:: json.Marshal(%v) = %s, want %s", i, tt.d, b, tt.want)
+		}
+
+		got := NewDuration(0, tt.d.GetLayout())
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Errorf("#%d:: json.Unmarshal(%s) error = %v", i, b, err)
+			continue
+		}
+		if got.Duration != tt.d.Duration {
+			t.Errorf("#%d:: json.Unmarshal(%s) = %v, want %v", i, b, got.Duration, tt.d.Duration)
+		}
+	}
+}
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	var config struct {
+		Timeout Duration `json:"timeout"`
+		TTL     Duration `json:"ttl"`
+	}
+	config.TTL = NewDuration(0, LayoutDurationSeconds)
+	if err := json.Unmarshal([]byte(`{"timeout": "1w2d", "ttl": 3600}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	if want := 9 * 24 * time.Hour; config.Timeout.Duration != want {
+		t.Errorf("Timeout = %v, want %v", config.Timeout.Duration, want)
+	}
+	if want := time.Hour; config.TTL.Duration != want {
+		t.Errorf("TTL = %v, want %v", config.TTL.Duration, want)
+	}
+
+	tests := [...]struct {
+		layout string
+		data   string
+	}{
+		0: {LayoutDuration, `3600`},
+		1: {LayoutDuration, `"1mo"`},
+		2: {LayoutDurationSeconds, `"1h"`},
+		3: {LayoutDurationSeconds, `1e300`},
+		4: {"unknown", `"1h"`},
+	}
+	for i, tt := range tests {
+		d := NewDuration(0, tt.layout)
+		if err := json.Unmarshal([]byte(tt.data), &d); err == nil {
+			t.Errorf("#%d:: json.Unmarshal(%s) in %q succeeded, want error", i, tt.data, tt.layout)
+		}
+	}
+}
+
+func TestDurationMarshalText(t *testing.T) {
+	d := NewDuration(36 * time.Hour)
+	b, err := d.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "1d12h" {
+		t.Errorf("MarshalText() = %s, want 1d12h", b)
+	}
+	var got Duration
+	if err := got.UnmarshalText(b); err != nil || got.Duration != d.Duration {
+		t.Errorf("UnmarshalText(%s) = %v, %v, want %v", b, got.Duration, err, d.Duration)
+	}
+	if _, err := NewDuration(0, "unknown").MarshalText(); err == nil {
+		t.Error("MarshalText() with unknown layout succeeded, want error")
+	}
+}
@@ -0,0 +1,57 @@
+package toki
+
+// scale returns p with every field multiplied by n.
+func (p Period) scale(n int) Period {
+	return Period{
+		Years:       p.Years * n,
+		Months:      p.Months * n,
+		Days:        p.Days * n,
+		Hours:       p.Hours * n,
+		Minutes:     p.Minutes * n,
+		Seconds:     p.Seconds * n,
+		Nanoseconds: p.Nanoseconds * n,
+	}
+}
+
+// everyStep returns the nth value of the sequence of Every, and reports
+// whether it comes before until in the direction of the sequence.
+func (t Toki) everyStep(step Period, until Toki, forward bool, n int) (Toki, bool) {
+	v := t.AddPeriod(step.scale(n))
+	if forward {
+		return v, v.Before(until)
+	}
+	return v, v.After(until)
+}
+
+// everyForward reports whether step moves t forward in time. It panics
+// if step does not move t at all, or if its fields have mixed signs, as
+// the sequence could then turn back and never reach until.
+func (t Toki) everyForward(step Period) bool {
+	var pos, neg bool
+	for _, v := range [...]int{step.Years, step.Months, step.Days, step.Hours, step.Minutes, step.Seconds, step.Nanoseconds} {
+		pos = pos || v > 0
+		neg = neg || v < 0
+	}
+	if pos && neg {
+		panic("toki: Every with a step of mixed signs")
+	}
+	next := t.AddPeriod(step)
+	if next.Equal(t) {
+		panic("toki: Every with a zero step")
+	}
+	return next.After(t)
+}
+
+// EverySlice returns the values of Every as a slice, for use without
+// range-over-func iterators.
+func (t Toki) EverySlice(step Period, until Toki) []Toki {
+	forward := t.everyForward(step)
+	var ts []Toki
+	for n := 0; ; n++ {
+		v, ok := t.everyStep(step, until, forward, n)
+		if !ok {
+			return ts
+		}
+		ts = append(ts, v)
+	}
+}
@@ -0,0 +1,25 @@
+//go:build go1.23
+
+package toki
+
+import "iter"
+
+// Every returns an iterator over t, t+step, t+2*step and so on, up to but
+// excluding until. The nth value is t.AddPeriod of step times n, computed
+// from t rather than from the previous value, so that months clamped to a
+// shorter month do not drift: monthly from January 31 gives February 28,
+// then March 31. Days follow the wall clock of t's location across DST
+// transitions. A negative step counts down to until. Every panics if step
+// is zero or if its fields have mixed signs, like Period{Months: 1,
+// Days: -30}.
+func (t Toki) Every(step Period, until Toki) iter.Seq[Toki] {
+	forward := t.everyForward(step)
+	return func(yield func(Toki) bool) {
+		for n := 0; ; n++ {
+			v, ok := t.everyStep(step, until, forward, n)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
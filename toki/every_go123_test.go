@@ -0,0 +1,45 @@
+//go:build go1.23
+
+package toki
+
+import "testing"
+
+func TestEvery(t *testing.T) {
+	start := Date(2023, January, 31, 0, 0, 0, 0, UTC, "2006-01-02")
+	until := Date(2024, January, 1, 0, 0, 0, 0, UTC)
+
+	want := start.EverySlice(Period{Months: 1}, until)
+	var got []Toki
+	for v := range start.Every(Period{Months: 1}, until) {
+		got = append(got, v)
+	}
+	if len(got) != 12 || len(got) != len(want) {
+		t.Fatalf("Every() yielded %d values, want 12", len(got))
+	}
+	for i := range got {
+		if !got[i].Equal(want[i]) || got[i].GetLayout() != "2006-01-02" {
+			t.Errorf("#%d:: Every() = %v in %q, want %v", i, got[i], got[i].GetLayout(), want[i])
+		}
+	}
+
+	n := 0
+	for range start.Every(Period{Days: 1}, until) {
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Errorf("Every() stopped after %d values, want 3", n)
+	}
+}
+
+func TestEveryMixedSigns(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Every with a step of mixed signs did not panic")
+		}
+	}()
+	s := Date(2023, January, 31, 0, 0, 0, 0, UTC)
+	for range s.Every(Period{Months: 1, Days: -30}, s.AddDate(-1, 0, 0)) {
+	}
+}
@@ -0,0 +1,88 @@
+package toki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEverySlice(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [...]struct {
+		t     Toki
+		step  Period
+		until Toki
+		want  []Toki
+	}{
+		// Anchored to January 31 rather than drifting to the 28th.
+		0: {Date(2023, January, 31, 0, 0, 0, 0, UTC), Period{Months: 1}, Date(2023, May, 1, 0, 0, 0, 0, UTC), []Toki{
+			Date(2023, January, 31, 0, 0, 0, 0, UTC),
+			Date(2023, February, 28, 0, 0, 0, 0, UTC),
+			Date(2023, March, 31, 0, 0, 0, 0, UTC),
+			Date(2023, April, 30, 0, 0, 0, 0, UTC),
+		}},
+		// until is excluded.
+		1: {Date(2023, October, 16, 0, 0, 0, 0, UTC), Period{Days: 7}, Date(2023, October, 30, 0, 0, 0, 0, UTC), []Toki{
+			Date(2023, October, 16, 0, 0, 0, 0, UTC),
+			Date(2023, October, 23, 0, 0, 0, 0, UTC),
+		}},
+		// Days keep the wall clock across the start of DST.
+		2: {Date(2023, March, 11, 9, 0, 0, 0, la), Period{Days: 1}, Date(2023, March, 13, 12, 0, 0, 0, la), []Toki{
+			Date(2023, March, 11, 9, 0, 0, 0, la),
+			Date(2023, March, 12, 9, 0, 0, 0, la),
+			Date(2023, March, 13, 9, 0, 0, 0, la),
+		}},
+		// Hours are absolute.
+		3: {Date(2023, March, 12, 0, 0, 0, 0, la), Period{Hours: 1}, Date(2023, March, 12, 4, 0, 0, 0, la), []Toki{
+			Date(2023, March, 12, 0, 0, 0, 0, la),
+			Date(2023, March, 12, 1, 0, 0, 0, la),
+			Date(2023, March, 12, 3, 0, 0, 0, la),
+		}},
+		4: {Date(2024, February, 29, 0, 0, 0, 0, UTC), Period{Years: -1}, Date(2021, January, 1, 0, 0, 0, 0, UTC), []Toki{
+			Date(2024, February, 29, 0, 0, 0, 0, UTC),
+			Date(2023, February, 28, 0, 0, 0, 0, UTC),
+			Date(2022, February, 28, 0, 0, 0, 0, UTC),
+			Date(2021, February, 28, 0, 0, 0, 0, UTC),
+		}},
+		5: {Date(2023, October, 16, 0, 0, 0, 0, UTC), Period{Days: 1}, Date(2023, October, 16, 0, 0, 0, 0, UTC), nil},
+	}
+
+	for i, tt := range tests {
+		got := tt.t.EverySlice(tt.step, tt.until)
+		if len(got) != len(tt.want) {
+			t.Errorf("#%d:: EverySlice(%v, %v) = %v, want %v", i, tt.step, tt.until, got, tt.want)
+			continue
+		}
+		for j := range got {
+			if !got[j].Equal(tt.want[j]) {
+				t.Errorf("#%d:: EverySlice(%v, %v)[%d] = %v, want %v", i, tt.step, tt.until, j, got[j], tt.want[j])
+			}
+		}
+	}
+}
+
+func TestEverySliceZeroStep(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("EverySlice with a zero step did not panic")
+		}
+	}()
+	Date(2023, October, 16, 0, 0, 0, 0, UTC).EverySlice(Period{}, Date(2023, October, 17, 0, 0, 0, 0, UTC))
+}
+
+func TestEverySliceMixedSigns(t *testing.T) {
+	s := Date(2023, January, 31, 0, 0, 0, 0, UTC)
+	for i, step := range []Period{{Months: 1, Days: -30}, {Hours: -1, Seconds: 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("#%d:: EverySlice(%v) did not panic", i, step)
+				}
+			}()
+			s.EverySlice(step, s.AddDate(-1, 0, 0))
+		}()
+	}
+}
@@ -9,5 +9,5 @@ func ForceUSPacificForTesting() {
 	if err != nil {
 		loc = time.FixedZone("America/Los_Angeles", -8*60*60)
 	}
-	time.Local = loc
+	SetDefaultLocation(loc)
 }
@@ -0,0 +1,232 @@
+package toki
+
+// JapaneseHolidays is a HolidayProvider of the national holidays of Japan
+// under the Act on National Holidays, which took effect on July 20, 1948,
+// including substitute holidays (振替休日), citizens' holidays (国民の休日)
+// and the one-off holidays of imperial events. Holidays are computed, not
+// looked up, so future years follow the current law. The equinox days
+// are approximated by the formulas of the National Astronomical
+// Observatory of Japan, valid until 2150; later equinoxes are not
+// reported.
+//
+// Name is the English name of the returned holiday and LocalName the
+// Japanese one.
+var JapaneseHolidays HolidayProvider = japaneseHolidays{}
+
+type japaneseHolidays struct{}
+
+func (japaneseHolidays) Holiday(d CivilDate) (Holiday, bool) {
+	ja, en, ok := japaneseHoliday(d)
+	if !ok {
+		return Holiday{}, false
+	}
+	return Holiday{Date: d, Name: en, LocalName: ja}, true
+}
+
+// HolidayName returns the Japanese and English names of the national
+// holiday of Japan falling on the date of t in t's location, if any.
+func HolidayName(t Toki) (ja, en string, ok bool) {
+	return japaneseHoliday(CivilDateOf(t))
+}
+
+var (
+	holidayActStart     = CivilDate{1948, July, 20}
+	substituteStart     = CivilDate{1973, April, 12}
+	citizensHolidayFrom = CivilDate{1985, December, 27}
+	holidayReform2007   = CivilDate{2007, January, 1}
+)
+
+// japaneseSpecialHolidays are the holidays decreed for imperial events.
+var japaneseSpecialHolidays = map[CivilDate][2]string{
+	{1959, April, 10}:    {"皇太子明仁親王の結婚の儀", "Marriage of Crown Prince Akihito"},
+	{1989, February, 24}: {"昭和天皇の大喪の礼", "State Funeral of Emperor Showa"},
+	{1990, November, 12}: {"即位礼正殿の儀", "Enthronement Ceremony"},
+	{1993, June, 9}:      {"皇太子徳仁親王の結婚の儀", "Marriage of Crown Prince Naruhito"},
+	{2019, May, 1}:       {"天皇の即位の日", "Enthronement Day"},
+	{2019, October, 22}:  {"即位礼正殿の儀", "Enthronement Ceremony"},
+}
+
+func japaneseHoliday(d CivilDate) (ja, en string, ok bool) {
+	if ja, en, ok = japaneseNationalHoliday(d); ok {
+		return ja, en, true
+	}
+	if isJapaneseSubstituteHoliday(d) {
+		return "振替休日", "Substitute Holiday", true
+	}
+	if isJapaneseCitizensHoliday(d) {
+		return "国民の休日", "Citizens' Holiday", true
+	}
+	return "", "", false
+}
+
+// isJapaneseSubstituteHoliday reports whether d is a substitute holiday
+// for a national holiday falling on a Sunday. Until 2006, only the Monday
+// after was one; since 2007, it is the first day after that is not a
+// national holiday.
+func isJapaneseSubstituteHoliday(d CivilDate) bool {
+	if d.Before(substituteStart) {
+		return false
+	}
+	if d.Before(holidayReform2007) {
+		prev := d.AddDays(-1)
+		_, _, ok := japaneseNationalHoliday(prev)
+		return ok && prev.Weekday() == Sunday
+	}
+	for prev := d.AddDays(-1); ; prev = prev.AddDays(-1) {
+		if _, _, ok := japaneseNationalHoliday(prev); !ok {
+			return false
+		}
+		if prev.Weekday() == Sunday {
+			return true
+		}
+	}
+}
+
+// isJapaneseCitizensHoliday reports whether d is a day between two
+// national holidays, which was a holiday unless it fell on a Sunday or a
+// substitute holiday until 2006, and always is since 2007.
+func isJapaneseCitizensHoliday(d CivilDate) bool {
+	if d.Before(citizensHolidayFrom) {
+		return false
+	}
+	if d.Before(holidayReform2007) && (d.Weekday() == Sunday || isJapaneseSubstituteHoliday(d)) {
+		return false
+	}
+	if _, _, ok := japaneseNationalHoliday(d.AddDays(-1)); !ok {
+		return false
+	}
+	_, _, ok := japaneseNationalHoliday(d.AddDays(1))
+	return ok
+}
+
+// japaneseNationalHoliday returns the national holiday (国民の祝日) on d,
+// excluding substitute and citizens' holidays.
+func japaneseNationalHoliday(d CivilDate) (ja, en string, ok bool) {
+	if d.Before(holidayActStart) {
+		return "", "", false
+	}
+	if names, ok := japaneseSpecialHolidays[d]; ok {
+		return names[0], names[1], true
+	}
+
+	y, day := d.Year, d.Day
+	switch d.Month {
+	case January:
+		switch {
+		case day == 1:
+			return "元日", "New Year's Day", true
+		case y <= 1999 && day == 15, y >= 2000 && isNthWeekday(d, 2, Monday):
+			return "成人の日", "Coming of Age Day", true
+		}
+	case February:
+		switch {
+		case y >= 1967 && day == 11:
+			return "建国記念の日", "National Foundation Day", true
+		case y >= 2020 && day == 23:
+			return "天皇誕生日", "The Emperor's Birthday", true
+		}
+	case March:
+		if day == vernalEquinoxDay(y) {
+			return "春分の日", "Vernal Equinox Day", true
+		}
+	case April:
+		if day == 29 {
+			switch {
+			case y <= 1988:
+				return "天皇誕生日", "The Emperor's Birthday", true
+			case y <= 2006:
+				return "みどりの日", "Greenery Day", true
+			default:
+				return "昭和の日", "Showa Day", true
+			}
+		}
+	case May:
+		switch {
+		case day == 3:
+			return "憲法記念日", "Constitution Memorial Day", true
+		case y >= 2007 && day == 4:
+			return "みどりの日", "Greenery Day", true
+		case day == 5:
+			return "こどもの日", "Children's Day", true
+		}
+	case July:
+		switch {
+		case y == 2020 && day == 23, y == 2021 && day == 22,
+			y >= 1996 && y <= 2002 && day == 20,
+			y >= 2003 && y != 2020 && y != 2021 && isNthWeekday(d, 3, Monday):
+			return "海の日", "Marine Day", true
+		case y == 2020 && day == 24, y == 2021 && day == 23:
+			return "スポーツの日", "Sports Day", true
+		}
+	case August:
+		if y == 2020 && day == 10 || y == 2021 && day == 8 ||
+			y >= 2016 && y != 2020 && y != 2021 && day == 11 {
+			return "山の日", "Mountain Day", true
+		}
+	case September:
+		switch {
+		case y >= 1966 && y <= 2002 && day == 15, y >= 2003 && isNthWeekday(d, 3, Monday):
+			return "敬老の日", "Respect for the Aged Day", true
+		case day == autumnalEquinoxDay(y):
+			return "秋分の日", "Autumnal Equinox Day", true
+		}
+	case October:
+		switch {
+		case y >= 1966 && y <= 1999 && day == 10, y >= 2000 && y <= 2019 && isNthWeekday(d, 2, Monday):
+			return "体育の日", "Health and Sports Day", true
+		case y >= 2022 && isNthWeekday(d, 2, Monday):
+			return "スポーツの日", "Sports Day", true
+		}
+	case November:
+		switch day {
+		case 3:
+			return "文化の日", "Culture Day", true
+		case 23:
+			return "勤労感謝の日", "Labour Thanksgiving Day", true
+		}
+	case December:
+		if y >= 1989 && y <= 2018 && day == 23 {
+			return "天皇誕生日", "The Emperor's Birthday", true
+		}
+	}
+	return "", "", false
+}
+
+// isNthWeekday reports whether d is the nth wd of its month.
+func isNthWeekday(d CivilDate, n int, wd Weekday) bool {
+	return d.Weekday() == wd && (d.Day-1)/7+1 == n
+}
+
+// vernalEquinoxDay returns the day in March of the vernal equinox in
+// Japan, or 0 if year is outside [1900, 2150].
+func vernalEquinoxDay(year int) int {
+	switch {
+	case year < 1900 || year > 2150:
+		return 0
+	case year < 1980:
+		return equinoxDay(20.8357, year, 1983)
+	case year < 2100:
+		return equinoxDay(20.8431, year, 1980)
+	}
+	return equinoxDay(21.8510, year, 1980)
+}
+
+// autumnalEquinoxDay returns the day in September of the autumnal
+// equinox in Japan, or 0 if year is outside [1900, 2150].
+func autumnalEquinoxDay(year int) int {
+	switch {
+	case year < 1900 || year > 2150:
+		return 0
+	case year < 1980:
+		return equinoxDay(23.2588, year, 1983)
+	case year < 2100:
+		return equinoxDay(23.2488, year, 1980)
+	}
+	return equinoxDay(24.2488, year, 1980)
+}
+
+// equinoxDay evaluates the approximation of the equinox day, where the
+// conversions to int truncate towards zero like the published formulas.
+func equinoxDay(base float64, year, leapBase int) int {
+	return int(base + 0.242194*float64(year-1980) - float64((year-leapBase)/4))
+}
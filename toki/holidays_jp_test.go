@@ -0,0 +1,151 @@
+package toki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJapaneseHolidaysYears(t *testing.T) {
+	tests := map[int][]CivilDate{
+		2019: {
+			{2019, January, 1}, {2019, January, 14}, {2019, February, 11}, {2019, March, 21},
+			{2019, April, 29}, {2019, April, 30}, {2019, May, 1}, {2019, May, 2},
+			{2019, May, 3}, {2019, May, 4}, {2019, May, 5}, {2019, May, 6},
+			{2019, July, 15}, {2019, August, 11}, {2019, August, 12}, {2019, September, 16},
+			{2019, September, 23}, {2019, October, 14}, {2019, October, 22}, {2019, November, 3},
+			{2019, November, 4}, {2019, November, 23},
+		},
+		2020: {
+			{2020, January, 1}, {2020, January, 13}, {2020, February, 11}, {2020, February, 23},
+			{2020, February, 24}, {2020, March, 20}, {2020, April, 29}, {2020, May, 3},
+			{2020, May, 4}, {2020, May, 5}, {2020, May, 6}, {2020, July, 23},
+			{2020, July, 24}, {2020, August, 10}, {2020, September, 21}, {2020, September, 22},
+			{2020, November, 3}, {2020, November, 23},
+		},
+		2021: {
+			{2021, January, 1}, {2021, January, 11}, {2021, February, 11}, {2021, February, 23},
+			{2021, March, 20}, {2021, April, 29}, {2021, May, 3}, {2021, May, 4},
+			{2021, May, 5}, {2021, July, 22}, {2021, July, 23}, {2021, August, 8},
+			{2021, August, 9}, {2021, September, 20}, {2021, September, 23}, {2021, November, 3},
+			{2021, November, 23},
+		},
+		2023: {
+			{2023, January, 1}, {2023, January, 2}, {2023, January, 9}, {2023, February, 11},
+			{2023, February, 23}, {2023, March, 21}, {2023, April, 29}, {2023, May, 3},
+			{2023, May, 4}, {2023, May, 5}, {2023, July, 17}, {2023, August, 11},
+			{2023, September, 18}, {2023, September, 23}, {2023, October, 9}, {2023, November, 3},
+			{2023, November, 23},
+		},
+		1988: {
+			{1988, January, 1}, {1988, January, 15}, {1988, February, 11}, {1988, March, 20},
+			{1988, March, 21}, {1988, April, 29}, {1988, May, 3}, {1988, May, 4},
+			{1988, May, 5}, {1988, September, 15}, {1988, September, 23}, {1988, October, 10},
+			{1988, November, 3}, {1988, November, 23},
+		},
+	}
+
+	for year, want := range tests {
+		var got []CivilDate
+		for d := (CivilDate{year, January, 1}); d.Year == year; d = d.AddDays(1) {
+			if _, ok := JapaneseHolidays.Holiday(d); ok {
+				got = append(got, d)
+			}
+		}
+		if len(got) != len(want) {
+			t.Errorf("%d: got %d holidays %v, want %d %v", year, len(got), got, len(want), want)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%d: holiday #%d = %v, want %v", year, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func TestJapaneseHolidayNames(t *testing.T) {
+	tests := [...]struct {
+		date   CivilDate
+		ja, en string
+		ok     bool
+	}{
+		0:  {CivilDate{2023, January, 2}, "振替休日", "Substitute Holiday", true},
+		1:  {CivilDate{2023, October, 9}, "スポーツの日", "Sports Day", true},
+		2:  {CivilDate{2019, October, 14}, "体育の日", "Health and Sports Day", true},
+		3:  {CivilDate{2006, April, 29}, "みどりの日", "Greenery Day", true},
+		4:  {CivilDate{2007, April, 29}, "昭和の日", "Showa Day", true},
+		5:  {CivilDate{1988, April, 29}, "天皇誕生日", "The Emperor's Birthday", true},
+		6:  {CivilDate{2018, December, 23}, "天皇誕生日", "The Emperor's Birthday", true},
+		7:  {CivilDate{2019, December, 23}, "", "", false},
+		8:  {CivilDate{2019, May, 1}, "天皇の即位の日", "Enthronement Day", true},
+		9:  {CivilDate{1989, February, 24}, "昭和天皇の大喪の礼", "State Funeral of Emperor Showa", true},
+		10: {CivilDate{2023, October, 16}, "", "", false},
+		// The first substitute holiday, for the Emperor's Birthday.
+		11: {CivilDate{1973, April, 30}, "振替休日", "Substitute Holiday", true},
+		// Before substitute holidays were introduced.
+		12: {CivilDate{1973, February, 12}, "", "", false},
+		// Days between Respect for the Aged Day and the autumnal equinox.
+		13: {CivilDate{2009, September, 22}, "国民の休日", "Citizens' Holiday", true},
+		14: {CivilDate{2015, September, 22}, "国民の休日", "Citizens' Holiday", true},
+		15: {CivilDate{2026, September, 22}, "国民の休日", "Citizens' Holiday", true},
+		// May 4 was a substitute holiday, not a citizens' holiday, when
+		// May 3 fell on a Sunday.
+		16: {CivilDate{1998, May, 4}, "振替休日", "Substitute Holiday", true},
+		// Since 2007, a substitute holiday moves past consecutive holidays.
+		17: {CivilDate{2008, May, 6}, "振替休日", "Substitute Holiday", true},
+		18: {CivilDate{1948, July, 19}, "", "", false},
+		19: {CivilDate{1948, November, 3}, "文化の日", "Culture Day", true},
+	}
+
+	for i, tt := range tests {
+		ja, en, ok := HolidayName(tt.date.In(UTC))
+		if ja != tt.ja || en != tt.en || ok != tt.ok {
+			t.Errorf("#%d:: HolidayName(%v) = %q, %q, %t, want %q, %q, %t", i, tt.date, ja, en, ok, tt.ja, tt.en, tt.ok)
+		}
+		h, ok := JapaneseHolidays.Holiday(tt.date)
+		if ok != tt.ok || (ok && (h.Date != tt.date || h.Name != tt.en || h.LocalName != tt.ja)) {
+			t.Errorf("#%d:: Holiday(%v) = %+v, %t", i, tt.date, h, ok)
+		}
+	}
+}
+
+func TestEquinoxDays(t *testing.T) {
+	tests := [...]struct {
+		year             int
+		vernal, autumnal int
+	}{
+		0: {1960, 20, 23},
+		1: {1979, 21, 24},
+		2: {1980, 20, 23},
+		3: {2000, 20, 23},
+		4: {2012, 20, 22},
+		5: {2024, 20, 22},
+		6: {2025, 20, 23},
+		7: {2099, 20, 23},
+		8: {2100, 20, 23},
+		9: {2151, 0, 0},
+	}
+
+	for i, tt := range tests {
+		if got := vernalEquinoxDay(tt.year); got != tt.vernal {
+			t.Errorf("#%d:: vernalEquinoxDay(%d) = %d, want %d", i, tt.year, got, tt.vernal)
+		}
+		if got := autumnalEquinoxDay(tt.year); got != tt.autumnal {
+			t.Errorf("#%d:: autumnalEquinoxDay(%d) = %d, want %d", i, tt.year, got, tt.autumnal)
+		}
+	}
+}
+
+func TestJapaneseBusinessCalendar(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cal := &BusinessCalendar{Holidays: JapaneseHolidays}
+
+	// Golden Week 2023.
+	got := Date(2023, May, 2, 9, 0, 0, 0, tokyo).NextBusinessDay(cal)
+	if want := Date(2023, May, 8, 9, 0, 0, 0, tokyo); !got.Equal(want) {
+		t.Errorf("NextBusinessDay() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,294 @@
+package toki
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// A Rounding selects how Humanize rounds an amount of time to a whole
+// number of units.
+type Rounding int
+
+const (
+	// RoundHalfUp rounds to the nearest unit, halves away from zero.
+	RoundHalfUp Rounding = iota
+	// RoundDown truncates to the unit below.
+	RoundDown
+)
+
+// HumanizeThresholds are the amounts of each unit from which Humanize
+// switches to the next larger unit, so that 45 minutes are "an hour" with
+// the default Minutes of 45.
+type HumanizeThresholds struct {
+	Seconds int
+	Minutes int
+	Hours   int
+	Days    int
+	Months  int
+}
+
+// DefaultHumanizeThresholds are the thresholds used when none are set.
+var DefaultHumanizeThresholds = HumanizeThresholds{
+	Seconds: 45,
+	Minutes: 45,
+	Hours:   22,
+	Days:    26,
+	Months:  11,
+}
+
+// HumanizeOptions configure Humanize and HumanizeDuration.
+type HumanizeOptions struct {
+	// Locale is the language tag of the phrases, resolved like
+	// LoadLocale. There are phrases for "en" (the default) and "ja";
+	// other tags fall back to English.
+	Locale string
+
+	// Thresholds replace the fields of DefaultHumanizeThresholds that
+	// they set.
+	Thresholds HumanizeThresholds
+
+	// Rounding selects how the amount of the chosen unit is rounded.
+	Rounding Rounding
+
+	// Precise lists exact amounts of the largest units instead, as in
+	// "2 days 4 hours ago". Smaller units are truncated.
+	Precise bool
+
+	// Units is the maximum number of units listed in precise mode, 2 if
+	// zero.
+	Units int
+}
+
+// humanizeLocale holds the phrases of a language.
+type humanizeLocale struct {
+	future, past string // patterns of relative phrases
+	now          string
+	fewSeconds   string
+	one          map[Unit]string // a single unit in approximate mode
+	count        func(n int, u Unit) string
+	sep          string // separator between units in precise mode
+}
+
+var englishUnitNames = map[Unit]string{
+	UnitSecond: "second",
+	UnitMinute: "minute",
+	UnitHour:   "hour",
+	UnitDay:    "day",
+	UnitMonth:  "month",
+	UnitYear:   "year",
+}
+
+var japaneseUnitNames = map[Unit]string{
+	UnitSecond: "秒",
+	UnitMinute: "分",
+	UnitHour:   "時間",
+	UnitDay:    "日",
+	UnitMonth:  "か月",
+	UnitYear:   "年",
+}
+
+// humanizeLocales holds the phrases by the Tag of the built-in Locale.
+var humanizeLocales = map[string]*humanizeLocale{
+	"en": {
+		future:     "in %s",
+		past:       "%s ago",
+		now:        "just now",
+		fewSeconds: "a few seconds",
+		one: map[Unit]string{
+			UnitSecond: "a second",
+			UnitMinute: "a minute",
+			UnitHour:   "an hour",
+			UnitDay:    "a day",
+			UnitMonth:  "a month",
+			UnitYear:   "a year",
+		},
+		count: func(n int, u Unit) string {
+			if n == 1 {
+				return "1 " + englishUnitNames[u]
+			}
+			return fmt.Sprintf("%d %ss", n, englishUnitNames[u])
+		},
+		sep: " ",
+	},
+	"ja": {
+		future:     "%s後",
+		past:       "%s前",
+		now:        "たった今",
+		fewSeconds: "数秒",
+		one: map[Unit]string{
+			UnitSecond: "1秒",
+			UnitMinute: "1分",
+			UnitHour:   "1時間",
+			UnitDay:    "1日",
+			UnitMonth:  "1か月",
+			UnitYear:   "1年",
+		},
+		count: func(n int, u Unit) string {
+			return fmt.Sprintf("%d%s", n, japaneseUnitNames[u])
+		},
+		sep: "",
+	},
+}
+
+func (o HumanizeOptions) locale() *humanizeLocale {
+	if l, err := LoadLocale(o.Locale); err == nil {
+		if h, ok := humanizeLocales[l.Tag]; ok {
+			return h
+		}
+	}
+	return humanizeLocales["en"]
+}
+
+func (o HumanizeOptions) thresholds() HumanizeThresholds {
+	th := o.Thresholds
+	if th.Seconds == 0 {
+		th.Seconds = DefaultHumanizeThresholds.Seconds
+	}
+	if th.Minutes == 0 {
+		th.Minutes = DefaultHumanizeThresholds.Minutes
+	}
+	if th.Hours == 0 {
+		th.Hours = DefaultHumanizeThresholds.Hours
+	}
+	if th.Days == 0 {
+		th.Days = DefaultHumanizeThresholds.Days
+	}
+	if th.Months == 0 {
+		th.Months = DefaultHumanizeThresholds.Months
+	}
+	return th
+}
+
+func humanizeOptions(opts []HumanizeOptions) HumanizeOptions {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return HumanizeOptions{}
+}
+
+// The length of a day, and the average lengths of months and years over
+// the 400-year Gregorian cycle.
+const (
+	oneDay       = 24 * time.Hour
+	averageMonth = time.Duration(365.2425 / 12 * float64(oneDay))
+	averageYear  = time.Duration(365.2425 * float64(oneDay))
+)
+
+// Humanize describes t relative to ref, such as "3 hours ago" or
+// "in 2 days". By default, the elapsed time is rounded to a single unit
+// chosen by thresholds and months and years are of average length; in
+// precise mode, the calendar difference of Diff is listed instead.
+func (t Toki) Humanize(ref Toki, opts ...HumanizeOptions) string {
+	o := humanizeOptions(opts)
+	l := o.locale()
+	future := t.After(ref)
+
+	var s string
+	if o.Precise {
+		p := t.Diff(ref)
+		if !future {
+			p = p.Negate()
+		}
+		s = humanizeAmounts([]int{p.Years, p.Months, p.Days, p.Hours, p.Minutes, p.Seconds}, o, l)
+	} else {
+		d := t.Sub(ref)
+		if d < 0 {
+			d = -d
+		}
+		s = humanizeApprox(d, o, l)
+	}
+	switch {
+	case s == "":
+		return l.now
+	case future:
+		return fmt.Sprintf(l.future, s)
+	}
+	return fmt.Sprintf(l.past, s)
+}
+
+// HumanizeDuration describes the length of d, such as "3 hours", with
+// the options of Humanize. Precise mode lists days and smaller units, or
+// "0 seconds" for less than a second.
+func HumanizeDuration(d time.Duration, opts ...HumanizeOptions) string {
+	o := humanizeOptions(opts)
+	l := o.locale()
+	if d < 0 {
+		d = -d
+	}
+	if !o.Precise {
+		return humanizeApprox(d, o, l)
+	}
+	amounts := []int{0, 0,
+		int(d / oneDay),
+		int(d % oneDay / time.Hour),
+		int(d % time.Hour / time.Minute),
+		int(d % time.Minute / time.Second),
+	}
+	if s := humanizeAmounts(amounts, o, l); s != "" {
+		return s
+	}
+	return l.count(0, UnitSecond)
+}
+
+var preciseUnits = [...]Unit{UnitYear, UnitMonth, UnitDay, UnitHour, UnitMinute, UnitSecond}
+
+// humanizeAmounts lists the first units with a non-zero amount, in the
+// order of preciseUnits. It returns "" if all amounts are zero.
+func humanizeAmounts(amounts []int, o HumanizeOptions, l *humanizeLocale) string {
+	limit := o.Units
+	if limit <= 0 {
+		limit = 2
+	}
+	var parts []string
+	for i, n := range amounts {
+		if n == 0 {
+			if len(parts) > 0 {
+				// Keep the listed units adjacent.
+				break
+			}
+			continue
+		}
+		parts = append(parts, l.count(n, preciseUnits[i]))
+		if len(parts) == limit {
+			break
+		}
+	}
+	return strings.Join(parts, l.sep)
+}
+
+// humanizeApprox describes d with a single rounded unit.
+func humanizeApprox(d time.Duration, o HumanizeOptions, l *humanizeLocale) string {
+	th := o.thresholds()
+	round := func(unit time.Duration) int {
+		v := float64(d) / float64(unit)
+		if o.Rounding == RoundDown {
+			return int(v)
+		}
+		return int(math.Floor(v + 0.5))
+	}
+	amount := func(n int, u Unit) string {
+		if n <= 1 {
+			return l.one[u]
+		}
+		return l.count(n, u)
+	}
+
+	if s := round(time.Second); s < th.Seconds {
+		return l.fewSeconds
+	}
+	if m := round(time.Minute); m < th.Minutes {
+		return amount(m, UnitMinute)
+	}
+	if h := round(time.Hour); h < th.Hours {
+		return amount(h, UnitHour)
+	}
+	if n := round(oneDay); n < th.Days {
+		return amount(n, UnitDay)
+	}
+	if n := round(averageMonth); n < th.Months {
+		return amount(n, UnitMonth)
+	}
+	return amount(round(averageYear), UnitYear)
+}
@@ -0,0 +1,98 @@
+package toki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanize(t *testing.T) {
+	ref := Date(2023, October, 16, 12, 0, 0, 0, UTC)
+
+	tests := [...]struct {
+		t    Toki
+		opts []HumanizeOptions
+		want string
+	}{
+		0:  {ref, nil, "a few seconds ago"},
+		1:  {ref.Add(-30 * time.Second), nil, "a few seconds ago"},
+		2:  {ref.Add(30 * time.Second), nil, "in a few seconds"},
+		3:  {ref.Add(-50 * time.Second), nil, "a minute ago"},
+		4:  {ref.Add(-3 * time.Hour), nil, "3 hours ago"},
+		5:  {ref.Add(-44 * time.Minute), nil, "44 minutes ago"},
+		6:  {ref.Add(-45 * time.Minute), nil, "an hour ago"},
+		7:  {ref.Add(2*24*time.Hour + 4*time.Hour), nil, "in 2 days"},
+		8:  {ref.Add(2*24*time.Hour + 13*time.Hour), nil, "in 3 days"},
+		9:  {ref.Add(2*24*time.Hour + 13*time.Hour), []HumanizeOptions{{Rounding: RoundDown}}, "in 2 days"},
+		10: {ref.AddDate(0, -3, 0), nil, "3 months ago"},
+		11: {ref.AddDate(0, -11, 0), nil, "a year ago"},
+		12: {ref.AddDate(-5, 0, 0), nil, "5 years ago"},
+		13: {ref.Add(-3 * time.Hour), []HumanizeOptions{{Locale: "ja"}}, "3時間前"},
+		14: {ref.Add(2 * 24 * time.Hour), []HumanizeOptions{{Locale: "ja-JP"}}, "2日後"},
+		15: {ref.Add(-10 * time.Second), []HumanizeOptions{{Locale: "ja"}}, "数秒前"},
+		16: {ref.Add(-3 * time.Hour), []HumanizeOptions{{Locale: "xx"}}, "3 hours ago"},
+		// Thresholds.
+		17: {ref.Add(-30 * time.Second), []HumanizeOptions{{Thresholds: HumanizeThresholds{Seconds: 10, Minutes: 60, Hours: 24, Days: 30, Months: 12}}}, "a minute ago"},
+		18: {ref.Add(-50 * time.Minute), []HumanizeOptions{{Thresholds: HumanizeThresholds{Seconds: 10, Minutes: 60, Hours: 24, Days: 30, Months: 12}}}, "50 minutes ago"},
+		19: {ref.AddDate(0, 0, -28), []HumanizeOptions{{Thresholds: HumanizeThresholds{Seconds: 10, Minutes: 60, Hours: 24, Days: 30, Months: 12}}}, "28 days ago"},
+		// Precise mode.
+		20: {ref.Add(-(2*24*time.Hour + 4*time.Hour + 5*time.Minute)), []HumanizeOptions{{Precise: true}}, "2 days 4 hours ago"},
+		21: {ref.Add(2*24*time.Hour + 4*time.Hour + 5*time.Minute), []HumanizeOptions{{Precise: true, Units: 3}}, "in 2 days 4 hours 5 minutes"},
+		22: {ref.Add(24*time.Hour + 5*time.Minute), []HumanizeOptions{{Precise: true}}, "in 1 day"},
+		23: {ref.AddDate(-1, -2, 0), []HumanizeOptions{{Precise: true}}, "1 year 2 months ago"},
+		24: {ref, []HumanizeOptions{{Precise: true}}, "just now"},
+		25: {ref.Add(-(2*24*time.Hour + 4*time.Hour)), []HumanizeOptions{{Precise: true, Locale: "ja"}}, "2日4時間前"},
+		26: {ref, []HumanizeOptions{{Precise: true, Locale: "ja"}}, "たった今"},
+		// Partial thresholds keep the defaults of the other fields.
+		27: {ref.Add(-30 * time.Second), []HumanizeOptions{{Thresholds: HumanizeThresholds{Seconds: 10}}}, "a minute ago"},
+		28: {ref.Add(-3 * time.Hour), []HumanizeOptions{{Thresholds: HumanizeThresholds{Seconds: 10}}}, "3 hours ago"},
+		29: {ref.Add(-5 * time.Second), []HumanizeOptions{{Thresholds: HumanizeThresholds{Seconds: 10}}}, "a few seconds ago"},
+		30: {ref.Add(-23 * time.Hour), []HumanizeOptions{{Thresholds: HumanizeThresholds{Hours: 24}}}, "23 hours ago"},
+		31: {ref.AddDate(0, -3, 0), []HumanizeOptions{{Thresholds: HumanizeThresholds{Days: 30}}}, "3 months ago"},
+		// Tags are resolved like LoadLocale.
+		32: {ref.Add(-3 * time.Hour), []HumanizeOptions{{Locale: "JA_jp"}}, "3時間前"},
+		33: {ref.Add(-3 * time.Hour), []HumanizeOptions{{Locale: "ja-Jpan-JP"}}, "3時間前"},
+		// Built-in locales without phrases fall back to English.
+		34: {ref.Add(-3 * time.Hour), []HumanizeOptions{{Locale: "de"}}, "3 hours ago"},
+	}
+
+	for i, tt := range tests {
+		if got := tt.t.Humanize(ref, tt.opts...); got != tt.want {
+			t.Errorf("#%d:: %v.Humanize(%v) = %q, want %q", i, tt.t, ref, got, tt.want)
+		}
+	}
+}
+
+func TestHumanizeLocales(t *testing.T) {
+	for tag := range humanizeLocales {
+		if l, err := LoadLocale(tag); err != nil || l.Tag != tag {
+			t.Errorf("phrases of %q are not keyed by a built-in locale: %v", tag, err)
+		}
+	}
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := [...]struct {
+		d    time.Duration
+		opts []HumanizeOptions
+		want string
+	}{
+		0:  {3 * time.Hour, nil, "3 hours"},
+		1:  {-3 * time.Hour, nil, "3 hours"},
+		2:  {90 * time.Minute, nil, "2 hours"},
+		3:  {90 * time.Minute, []HumanizeOptions{{Rounding: RoundDown}}, "an hour"},
+		4:  {90 * time.Minute, []HumanizeOptions{{Precise: true}}, "1 hour 30 minutes"},
+		5:  {50*time.Hour + 10*time.Second, []HumanizeOptions{{Precise: true, Units: 4}}, "2 days 2 hours"},
+		6:  {90 * time.Minute, []HumanizeOptions{{Precise: true, Locale: "ja"}}, "1時間30分"},
+		7:  {0, []HumanizeOptions{{Precise: true}}, "0 seconds"},
+		8:  {400 * 24 * time.Hour, nil, "a year"},
+		9:  {500 * time.Millisecond, []HumanizeOptions{{Precise: true}}, "0 seconds"},
+		10: {-500 * time.Millisecond, []HumanizeOptions{{Precise: true, Locale: "ja"}}, "0秒"},
+		11: {time.Second, []HumanizeOptions{{Precise: true}}, "1 second"},
+	}
+
+	for i, tt := range tests {
+		if got := HumanizeDuration(tt.d, tt.opts...); got != tt.want {
+			t.Errorf("#%d:: HumanizeDuration(%v) = %q, want %q", i, tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Package ical writes iCalendar (RFC 5545) calendars of events, along with
+// the time zones their times are in.
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/usk81/toki"
+	"github.com/usk81/toki/rrule"
+)
+
+// DefaultProdID is the PRODID of a Calendar without one.
+const DefaultProdID = "-//usk81//toki//EN"
+
+// recurrenceYears is how long the time zones of a recurring event without
+// an UNTIL are described after its start.
+const recurrenceYears = 10
+
+// A Calendar is a VCALENDAR object of events.
+type Calendar struct {
+	ProdID string
+	Events []Event
+}
+
+// An Event is a VEVENT component.
+//
+// Start and End are written in UTC, or with the TZID of their location if
+// it is one of the IANA database, as with toki.LayoutICalendar. The
+// calendar then holds a VTIMEZONE for that location.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+
+	// Start is the DTSTART of the event, and End its DTEND, if not zero.
+	Start toki.Toki
+	End   toki.Toki
+
+	// AllDay writes Start and End as dates. End is then the day after
+	// the last day of the event.
+	AllDay bool
+
+	// RRule is the recurrence rule of the event, if not nil.
+	RRule *rrule.Rule
+
+	// Stamp is the DTSTAMP of the event, toki.Now if zero.
+	Stamp toki.Toki
+}
+
+// WriteTo writes c to w, with CRLF line breaks and lines folded at 75
+// octets. It implements io.WriterTo.
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	cw := &writer{w: w}
+	for i := range c.Events {
+		if err := c.Events[i].validate(); err != nil {
+			return 0, fmt.Errorf("ical: event %d: %w", i, err)
+		}
+	}
+
+	prodID := c.ProdID
+	if prodID == "" {
+		prodID = DefaultProdID
+	}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:" + escape(prodID))
+	for _, z := range c.zones() {
+		writeTimezone(cw, z.loc, z.from, z.to)
+	}
+	for i := range c.Events {
+		c.Events[i].write(cw)
+	}
+	cw.line("END:VCALENDAR")
+	return cw.n, cw.err
+}
+
+func (e *Event) validate() error {
+	switch {
+	case e.UID == "":
+		return fmt.Errorf("missing UID")
+	case e.Start.IsZero():
+		return fmt.Errorf("missing start")
+	case !e.End.IsZero() && e.End.Before(e.Start):
+		return fmt.Errorf("end %v is before start %v", e.End, e.Start)
+	}
+	return nil
+}
+
+func (e *Event) write(w *writer) {
+	stamp := e.Stamp
+	if stamp.IsZero() {
+		stamp = toki.Now()
+	}
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + escape(e.UID))
+	w.line("DTSTAMP:" + stamp.UTC().Format(toki.LayoutICalendar))
+	w.line(e.dateTime("DTSTART", e.Start))
+	if !e.End.IsZero() {
+		w.line(e.dateTime("DTEND", e.End))
+	}
+	if e.RRule != nil {
+		w.line("RRULE:" + e.RRule.String())
+	}
+	for _, p := range [...]struct{ name, value string }{
+		{"SUMMARY", e.Summary},
+		{"DESCRIPTION", e.Description},
+		{"LOCATION", e.Location},
+	} {
+		if p.value != "" {
+			w.line(p.name + ":" + escape(p.value))
+		}
+	}
+	w.line("END:VEVENT")
+}
+
+// dateTime returns the content line of a DATE or DATE-TIME property.
+func (e *Event) dateTime(name string, t toki.Toki) string {
+	if e.AllDay {
+		return name + ";VALUE=DATE:" + t.Format(toki.LayoutICalendarDate)
+	}
+	v := t.Format(toki.LayoutICalendar)
+	if strings.HasPrefix(v, "TZID=") {
+		return name + ";" + v
+	}
+	return name + ":" + v
+}
+
+// A zone is a location of the events of a calendar, with the span of
+// their times.
+type zone struct {
+	loc      *time.Location
+	from, to toki.Toki
+}
+
+// zones returns the locations written with a TZID by the events of c, in
+// order of appearance.
+func (c *Calendar) zones() []zone {
+	var zs []zone
+	index := map[string]int{}
+	add := func(t, until toki.Toki) {
+		if !strings.HasPrefix(t.Format(toki.LayoutICalendar), "TZID=") {
+			return
+		}
+		name := t.Location().String()
+		i, ok := index[name]
+		if !ok {
+			index[name] = len(zs)
+			zs = append(zs, zone{loc: t.Location(), from: t, to: until})
+			return
+		}
+		if t.Before(zs[i].from) {
+			zs[i].from = t
+		}
+		if until.After(zs[i].to) {
+			zs[i].to = until
+		}
+	}
+	for _, e := range c.Events {
+		if e.AllDay {
+			continue
+		}
+		until := e.Start
+		if e.End.After(until) {
+			until = e.End
+		}
+		if e.RRule != nil {
+			if e.RRule.Until.IsZero() {
+				until = until.AddDate(recurrenceYears, 0, 0)
+			} else if e.RRule.Until.After(until) {
+				until = e.RRule.Until
+			}
+		}
+		add(e.Start, until)
+		if !e.End.IsZero() {
+			add(e.End, until)
+		}
+	}
+	return zs
+}
+
+// A writer writes content lines, counting the bytes written and keeping
+// the first error.
+type writer struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+// maxLine is the length of a content line, in octets, after which it is
+// folded.
+const maxLine = 75
+
+// line writes a content line, folded without splitting UTF-8 sequences.
+func (w *writer) line(s string) {
+	var b strings.Builder
+	limit := maxLine
+	for len(s) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		b.WriteString(s[:i])
+		b.WriteString("\r\n ")
+		s = s[i:]
+		// The leading space of a continuation line counts.
+		limit = maxLine - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	w.write(b.String())
+}
+
+func (w *writer) write(s string) {
+	if w.err != nil {
+		return
+	}
+	n, err := io.WriteString(w.w, s)
+	w.n += int64(n)
+	w.err = err
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return textEscaper.Replace(s)
+}
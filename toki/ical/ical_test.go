@@ -0,0 +1,202 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/usk81/toki"
+	"github.com/usk81/toki/rrule"
+)
+
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestCalendarWriteTo(t *testing.T) {
+	ny := newYork(t)
+	stamp := toki.Date(2023, toki.October, 1, 0, 0, 0, 0, toki.UTC)
+	c := Calendar{Events: []Event{
+		{
+			UID:     "standup@example.com",
+			Summary: "Standup, daily; with a summary long enough to be folded past 75 octets",
+			Start:   toki.Date(2023, toki.October, 16, 10, 15, 0, 0, ny),
+			End:     toki.Date(2023, toki.October, 16, 10, 30, 0, 0, ny),
+			RRule:   rrule.MustParseRule("FREQ=WEEKLY;UNTIL=20240401T000000Z"),
+			Stamp:   stamp,
+		},
+		{
+			UID:      "release@example.com",
+			Summary:  "Release",
+			Location: "Room 1",
+			Start:    toki.Date(2023, toki.October, 20, 1, 0, 0, 0, toki.UTC),
+			Stamp:    stamp,
+		},
+		{
+			UID:    "offsite@example.com",
+			AllDay: true,
+			Start:  toki.Date(2023, toki.October, 16, 0, 0, 0, 0, ny),
+			End:    toki.Date(2023, toki.October, 18, 0, 0, 0, 0, ny),
+			Stamp:  stamp,
+		},
+	}}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//usk81//toki//EN",
+		"BEGIN:VTIMEZONE",
+		"TZID:America/New_York",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20230312T020000",
+		"TZOFFSETFROM:-0500",
+		"TZOFFSETTO:-0400",
+		"TZNAME:EDT",
+		"END:DAYLIGHT",
+		"BEGIN:STANDARD",
+		"DTSTART:20231105T020000",
+		"TZOFFSETFROM:-0400",
+		"TZOFFSETTO:-0500",
+		"TZNAME:EST",
+		"END:STANDARD",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20240310T020000",
+		"TZOFFSETFROM:-0500",
+		"TZOFFSETTO:-0400",
+		"TZNAME:EDT",
+		"END:DAYLIGHT",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"DTSTAMP:20231001T000000Z",
+		"DTSTART;TZID=America/New_York:20231016T101500",
+		"DTEND;TZID=America/New_York:20231016T103000",
+		"RRULE:FREQ=WEEKLY;UNTIL=20240401T000000Z",
+		"SUMMARY:Standup\\, daily\\; with a summary long enough to be folded past 75 o",
+		" ctets",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:release@example.com",
+		"DTSTAMP:20231001T000000Z",
+		"DTSTART:20231020T010000Z",
+		"SUMMARY:Release",
+		"LOCATION:Room 1",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:offsite@example.com",
+		"DTSTAMP:20231001T000000Z",
+		"DTSTART;VALUE=DATE:20231016",
+		"DTEND;VALUE=DATE:20231018",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	var b strings.Builder
+	n, err := c.WriteTo(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("WriteTo() wrote\n%s\nwant\n%s", got, want)
+	}
+	if n != int64(b.Len()) {
+		t.Errorf("WriteTo() = %d, wrote %d bytes", n, b.Len())
+	}
+}
+
+func TestCalendarWriteToFixedZone(t *testing.T) {
+	// A fixed zone named like a location of the database has no TZID.
+	cet := time.FixedZone("CET", 60*60)
+	c := Calendar{Events: []Event{{
+		UID:   "a@example.com",
+		Start: toki.Date(2023, toki.July, 1, 12, 0, 0, 0, cet),
+		End:   toki.Date(2023, toki.July, 1, 13, 0, 0, 0, cet),
+		Stamp: toki.Date(2023, toki.June, 1, 0, 0, 0, 0, toki.UTC),
+	}}}
+
+	var b strings.Builder
+	if _, err := c.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{"\r\nDTSTART:20230701T110000Z\r\n", "\r\nDTEND:20230701T120000Z\r\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteTo() wrote\n%s\nwant a line %q", got, strings.TrimSpace(want))
+		}
+	}
+	if strings.Contains(got, "VTIMEZONE") {
+		t.Errorf("WriteTo() wrote a VTIMEZONE for a fixed zone:\n%s", got)
+	}
+}
+
+func TestCalendarWriteToInvalid(t *testing.T) {
+	start := toki.Date(2023, toki.October, 16, 10, 0, 0, 0, toki.UTC)
+	tests := [...]struct {
+		event Event
+	}{
+		0: {Event{Start: start}},
+		1: {Event{UID: "a"}},
+		2: {Event{UID: "a", Start: start, End: start.Add(-time.Hour)}},
+	}
+
+	for i, tt := range tests {
+		c := Calendar{Events: []Event{tt.event}}
+		var b strings.Builder
+		if _, err := c.WriteTo(&b); err == nil || b.Len() != 0 {
+			t.Errorf("#%d:: WriteTo() = %v, wrote %q", i, err, b.String())
+		}
+	}
+}
+
+func TestWriterLine(t *testing.T) {
+	tests := [...]struct {
+		line string
+		want []string
+	}{
+		0: {"SUMMARY:short", []string{"SUMMARY:short"}},
+		1: {strings.Repeat("a", 75), []string{strings.Repeat("a", 75)}},
+		2: {strings.Repeat("a", 76), []string{strings.Repeat("a", 75), " a"}},
+		3: {strings.Repeat("a", 75+74+1), []string{strings.Repeat("a", 75), " " + strings.Repeat("a", 74), " a"}},
+		// Folds do not split UTF-8 sequences.
+		4: {strings.Repeat("a", 74) + "時間", []string{strings.Repeat("a", 74), " 時間"}},
+	}
+
+	for i, tt := range tests {
+		var b strings.Builder
+		w := &writer{w: &b}
+		w.line(tt.line)
+		got := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("#%d:: line(%q) wrote %q, want %q", i, tt.line, got, tt.want)
+		}
+		for _, l := range got {
+			if len(l) > maxLine || !utf8.ValidString(l) {
+				t.Errorf("#%d:: line(%q) wrote invalid line %q", i, tt.line, l)
+			}
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := [...]struct {
+		s, want string
+	}{
+		0: {"plain", "plain"},
+		1: {`a\b`, `a\\b`},
+		2: {"a;b,c", `a\;b\,c`},
+		3: {"line 1\nline 2\r\nline 3", `line 1\nline 2\nline 3`},
+	}
+
+	for i, tt := range tests {
+		if got := escape(tt.s); got != tt.want {
+			t.Errorf("#%d:: escape(%q) = %q, want %q", i, tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package ical
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+// WriteTimezone writes a VTIMEZONE component describing loc from from to
+// to: the observance in effect at from, and one STANDARD or DAYLIGHT
+// observance per transition until to, excluded. Observances are listed
+// one by one rather than summarized with rules.
+func WriteTimezone(w io.Writer, loc *time.Location, from, to toki.Toki) (int64, error) {
+	cw := &writer{w: w}
+	writeTimezone(cw, loc, from, to)
+	return cw.n, cw.err
+}
+
+func writeTimezone(w *writer, loc *time.Location, from, to toki.Toki) {
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + loc.String())
+	if tr, ok := toki.PrevTransition(loc, from.Add(time.Nanosecond)); ok {
+		writeObservance(w, tr)
+	} else {
+		// The zone in effect at from has always been.
+		t := from.In(loc)
+		name, offset := t.Zone()
+		writeObservance(w, toki.Transition{
+			When: t, Name: name, Offset: offset, IsDST: t.IsDST(),
+			PrevName: name, PrevOffset: offset, PrevIsDST: t.IsDST(),
+		})
+	}
+	for _, tr := range toki.Transitions(loc, from.Add(time.Nanosecond), to) {
+		writeObservance(w, tr)
+	}
+	w.line("END:VTIMEZONE")
+}
+
+// writeObservance writes the observance starting at tr. Its DTSTART is
+// the local time of the transition with the offset before it.
+func writeObservance(w *writer, tr toki.Transition) {
+	kind := "STANDARD"
+	if tr.IsDST {
+		kind = "DAYLIGHT"
+	}
+	w.line("BEGIN:" + kind)
+	w.line("DTSTART:" + tr.When.In(time.FixedZone("", tr.PrevOffset)).Format("20060102T150405"))
+	w.line("TZOFFSETFROM:" + formatOffset(tr.PrevOffset))
+	w.line("TZOFFSETTO:" + formatOffset(tr.Offset))
+	if tr.Name != "" {
+		w.line("TZNAME:" + escape(tr.Name))
+	}
+	w.line("END:" + kind)
+}
+
+// formatOffset formats an offset in seconds east of UTC as a UTC-OFFSET
+// value, like -0500, or +093930 with seconds.
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	h, m, s := offset/3600, offset/60%60, offset%60
+	if s != 0 {
+		return fmt.Sprintf("%c%02d%02d%02d", sign, h, m, s)
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, h, m)
+}
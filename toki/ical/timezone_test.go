@@ -0,0 +1,107 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+func TestWriteTimezone(t *testing.T) {
+	ny := newYork(t)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [...]struct {
+		loc      *time.Location
+		from, to toki.Toki
+		want     []string
+	}{
+		// The observance in effect at from starts before it.
+		0: {ny, toki.Date(2023, toki.January, 1, 0, 0, 0, 0, ny), toki.Date(2023, toki.June, 1, 0, 0, 0, 0, ny), []string{
+			"BEGIN:VTIMEZONE",
+			"TZID:America/New_York",
+			"BEGIN:STANDARD",
+			"DTSTART:20221106T020000",
+			"TZOFFSETFROM:-0400",
+			"TZOFFSETTO:-0500",
+			"TZNAME:EST",
+			"END:STANDARD",
+			"BEGIN:DAYLIGHT",
+			"DTSTART:20230312T020000",
+			"TZOFFSETFROM:-0500",
+			"TZOFFSETTO:-0400",
+			"TZNAME:EDT",
+			"END:DAYLIGHT",
+			"END:VTIMEZONE",
+		}},
+		// A transition at to is excluded.
+		1: {ny, toki.Date(2023, toki.April, 1, 0, 0, 0, 0, ny), toki.Date(2023, toki.November, 5, 6, 0, 0, 0, toki.UTC), []string{
+			"BEGIN:VTIMEZONE",
+			"TZID:America/New_York",
+			"BEGIN:DAYLIGHT",
+			"DTSTART:20230312T020000",
+			"TZOFFSETFROM:-0500",
+			"TZOFFSETTO:-0400",
+			"TZNAME:EDT",
+			"END:DAYLIGHT",
+			"END:VTIMEZONE",
+		}},
+		2: {tokyo, toki.Date(2023, toki.January, 1, 0, 0, 0, 0, tokyo), toki.Date(2024, toki.January, 1, 0, 0, 0, 0, tokyo), []string{
+			"BEGIN:VTIMEZONE",
+			"TZID:Asia/Tokyo",
+			"BEGIN:STANDARD",
+			"DTSTART:19510909T010000",
+			"TZOFFSETFROM:+1000",
+			"TZOFFSETTO:+0900",
+			"TZNAME:JST",
+			"END:STANDARD",
+			"END:VTIMEZONE",
+		}},
+		// A zone without transitions has a single observance.
+		3: {time.UTC, toki.Date(2023, toki.January, 1, 0, 0, 0, 0, toki.UTC), toki.Date(2024, toki.January, 1, 0, 0, 0, 0, toki.UTC), []string{
+			"BEGIN:VTIMEZONE",
+			"TZID:UTC",
+			"BEGIN:STANDARD",
+			"DTSTART:20230101T000000",
+			"TZOFFSETFROM:+0000",
+			"TZOFFSETTO:+0000",
+			"TZNAME:UTC",
+			"END:STANDARD",
+			"END:VTIMEZONE",
+		}},
+	}
+
+	for i, tt := range tests {
+		var b strings.Builder
+		if _, err := WriteTimezone(&b, tt.loc, tt.from, tt.to); err != nil {
+			t.Errorf("#%d:: WriteTimezone() error = %v", i, err)
+			continue
+		}
+		if got, want := b.String(), strings.Join(tt.want, "\r\n")+"\r\n"; got != want {
+			t.Errorf("#%d:: WriteTimezone(%v, %v, %v) wrote\n%s\nwant\n%s", i, tt.loc, tt.from, tt.to, got, want)
+		}
+	}
+}
+
+func TestFormatOffset(t *testing.T) {
+	tests := [...]struct {
+		offset int
+		want   string
+	}{
+		0: {0, "+0000"},
+		1: {9 * 60 * 60, "+0900"},
+		2: {-5 * 60 * 60, "-0500"},
+		3: {5*60*60 + 30*60, "+0530"},
+		4: {-(4*60*60 + 56*60 + 2), "-045602"},
+	}
+
+	for i, tt := range tests {
+		if got := formatOffset(tt.offset); got != tt.want {
+			t.Errorf("#%d:: formatOffset(%d) = %q, want %q", i, tt.offset, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package toki
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Layouts of the DATE and DATE-TIME values of iCalendar (RFC 5545). They
+// can be used as the layout of a Toki, with Format and with Parse, but not
+// combined with the layout elements of the time package.
+const (
+	// LayoutICalendar formats a time in UTC like 20231016T011500Z, and a
+	// time in a location of the IANA database with its TZID, like
+	// TZID=Asia/Tokyo:20231016T101500. Times in other locations, such as
+	// Local or a fixed zone, even one named like FixedZone("CET", 3600),
+	// are formatted in UTC. It parses those forms,
+	// along with floating times like 20231016T101500 and dates like
+	// 20231016, which are read in DefaultLocation.
+	LayoutICalendar = "icalendar"
+	// LayoutICalendarDate formats dates like 20231016.
+	LayoutICalendarDate = "icalendar_date"
+)
+
+// LayoutICalendarFloating is the layout of the time package for the
+// wall clock of a floating DATE-TIME of iCalendar, like 20231016T101500.
+const LayoutICalendarFloating = "20060102T150405"
+
+// Layouts of the time package for the other values of LayoutICalendar.
+const (
+	icalDate = "20060102"
+	icalUTC  = "20060102T150405Z"
+)
+
+func init() {
+	layoutCodecs[LayoutICalendar] = layoutCodec{
+		format: formatICalendar,
+		parse: func(value string) (time.Time, error) {
+			var tzid string
+			if rest, ok := cutPrefixFold(value, "TZID="); ok {
+				var found bool
+				if tzid, value, found = strings.Cut(rest, ":"); !found {
+					return time.Time{}, fmt.Errorf("toki: cannot parse %q as an iCalendar date-time", "TZID="+rest)
+				}
+			}
+			t, err := ParseICalendar(value, strings.Trim(tzid, `"`))
+			return t.Time, err
+		},
+	}
+	layoutCodecs[LayoutICalendarDate] = layoutCodec{
+		format: func(t time.Time) (string, error) { return t.Format(icalDate), nil },
+		parse: func(value string) (time.Time, error) {
+			if len(value) != len(icalDate) {
+				return time.Time{}, fmt.Errorf("toki: cannot parse %q as an iCalendar date", value)
+			}
+			t, err := ParseICalendar(value, "")
+			return t.Time, err
+		},
+	}
+}
+
+func formatICalendar(t time.Time) (string, error) {
+	if tzid := ianaName(t); tzid != "" {
+		return "TZID=" + tzid + ":" + t.Format(LayoutICalendarFloating), nil
+	}
+	return t.UTC().Format(icalUTC), nil
+}
+
+// ianaLocations caches the locations of the IANA database by name, with
+// nil for the names that are not in it.
+var ianaLocations sync.Map
+
+// ianaName returns the name of the location of t if it is a location of
+// the IANA database other than UTC, and "" otherwise.
+func ianaName(t time.Time) string {
+	name := t.Location().String()
+	switch name {
+	case "", "Local", "UTC":
+		return ""
+	}
+	// A fixed zone, like FixedZone("CET", 3600), has no transitions
+	// even when it bears the name of a location of the database.
+	if start, end := t.ZoneBounds(); start.IsZero() && end.IsZero() {
+		return ""
+	}
+	v, ok := ianaLocations.Load(name)
+	if !ok {
+		loc, _ := time.LoadLocation(name)
+		v, _ = ianaLocations.LoadOrStore(name, loc)
+	}
+	loc := v.(*Location)
+	if loc == nil {
+		return ""
+	}
+	// So does a location loaded from other data under such a name.
+	_, offset := t.Zone()
+	if _, want := t.In(loc).Zone(); offset != want {
+		return ""
+	}
+	return name
+}
+
+// ParseICalendar parses a DATE or DATE-TIME value of iCalendar, such as
+// 20231016, 20231016T101500 or 20231016T011500Z, along with the TZID
+// parameter of its property, if any. Local times and dates are read in
+// the location of tzid, or in DefaultLocation if it is empty, like
+// ParseICalendarInLocation.
+func ParseICalendar(value, tzid string, layouts ...string) (Toki, error) {
+	loc := DefaultLocation()
+	if tzid != "" {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return Toki{}, fmt.Errorf("toki: unknown TZID %q: %w", tzid, err)
+		}
+		loc = l
+	}
+	return ParseICalendarInLocation(value, loc, layouts...)
+}
+
+// ParseICalendarInLocation is like ParseICalendar, but reads local times
+// and dates in loc. A local time skipped or repeated by a transition is
+// read like TimeOfDay.On.
+func ParseICalendarInLocation(value string, loc *Location, layouts ...string) (Toki, error) {
+	bad := func() (Toki, error) {
+		return Toki{}, fmt.Errorf("toki: cannot parse %q as an iCalendar date-time", value)
+	}
+	switch {
+	case len(value) == len(icalDate):
+		t, err := time.Parse(icalDate, value)
+		if err != nil {
+			return bad()
+		}
+		return CivilDateOf(Toki{Time: t}).In(loc, layouts...), nil
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse(icalUTC, value)
+		if err != nil {
+			return bad()
+		}
+		return Toki{layout: setLayout(layouts...), Time: t}, nil
+	}
+	t, err := time.Parse(LayoutICalendarFloating, value)
+	if err != nil {
+		return bad()
+	}
+	w := Toki{Time: t}
+	return TimeOfDayOf(w).On(CivilDateOf(w), loc, layouts...), nil
+}
+
+// cutPrefixFold is like strings.CutPrefix, ignoring case.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
@@ -0,0 +1,174 @@
+package toki
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFormatICalendar(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cet, err := time.LoadLocation("CET")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [...]struct {
+		v      Toki
+		layout string
+		want   string
+	}{
+		0: {Date(2023, October, 16, 1, 15, 0, 0, UTC), LayoutICalendar, "20231016T011500Z"},
+		1: {Date(2023, October, 16, 10, 15, 0, 0, tokyo), LayoutICalendar, "TZID=Asia/Tokyo:20231016T101500"},
+		2: {Date(2023, March, 12, 3, 0, 0, 0, ny), LayoutICalendar, "TZID=America/New_York:20230312T030000"},
+		// Locations without a TZID are formatted in UTC.
+		3: {Date(2023, October, 16, 10, 15, 0, 0, FixedZone("JST", 9*60*60)), LayoutICalendar, "20231016T011500Z"},
+		4: {Date(2023, October, 16, 10, 15, 0, 0, FixedZone("", -5*60*60)), LayoutICalendar, "20231016T151500Z"},
+		5: {Date(2023, October, 16, 10, 15, 0, 0, tokyo), LayoutICalendarDate, "20231016"},
+		6: {Date(2023, October, 16, 23, 0, 0, 0, ny), LayoutICalendarDate, "20231016"},
+		// Fixed zones named like locations of the database.
+		7: {Date(2023, July, 1, 12, 0, 0, 0, FixedZone("CET", 60*60)), LayoutICalendar, "20230701T110000Z"},
+		8: {Date(2023, July, 1, 12, 0, 0, 0, FixedZone("EST", -5*60*60)), LayoutICalendar, "20230701T170000Z"},
+		9: {Date(2023, July, 1, 12, 0, 0, 0, FixedZone("Asia/Tokyo", 9*60*60)), LayoutICalendar, "20230701T030000Z"},
+		// A location of the database named like a zone.
+		10: {Date(2023, July, 1, 12, 0, 0, 0, cet), LayoutICalendar, "TZID=CET:20230701T120000"},
+	}
+
+	for i, tt := range tests {
+		if got := tt.v.Format(tt.layout); got != tt.want {
+			t.Errorf("#%d:: Format(%s) = %q, want %q", i, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestParseICalendar(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer SetDefaultLocation(tokyo)()
+
+	tests := [...]struct {
+		layout  string
+		value   string
+		want    Toki
+		wantErr bool
+	}{
+		0: {LayoutICalendar, "20231016T011500Z", Date(2023, October, 16, 1, 15, 0, 0, UTC), false},
+		1: {LayoutICalendar, "TZID=America/New_York:20231016T101500", Date(2023, October, 16, 10, 15, 0, 0, ny), false},
+		2: {LayoutICalendar, `tzid="America/New_York":20231016T101500`, Date(2023, October, 16, 10, 15, 0, 0, ny), false},
+		// Floating times and dates are read in the default location.
+		3: {LayoutICalendar, "20231016T101500", Date(2023, October, 16, 10, 15, 0, 0, tokyo), false},
+		4: {LayoutICalendar, "20231016", Date(2023, October, 16, 0, 0, 0, 0, tokyo), false},
+		5: {LayoutICalendar, "TZID=America/New_York:20231016", Date(2023, October, 16, 0, 0, 0, 0, ny), false},
+		// A skipped time is shifted by the gap, a repeated one is the
+		// first occurrence.
+		6: {LayoutICalendar, "TZID=America/New_York:20230312T023000", Date(2023, March, 12, 3, 30, 0, 0, ny), false},
+		7: {LayoutICalendar, "TZID=America/New_York:20231105T013000", Date(2023, November, 5, 1, 30, 0, 0, ny), false},
+		8: {LayoutICalendarDate, "20231016", Date(2023, October, 16, 0, 0, 0, 0, tokyo), false},
+		// Malformed.
+		9:  {LayoutICalendar, "2023-10-16T10:15:00Z", Toki{}, true},
+		10: {LayoutICalendar, "20231016T101500+0900", Toki{}, true},
+		11: {LayoutICalendar, "20231316", Toki{}, true},
+		12: {LayoutICalendar, "TZID=Mars/Olympus_Mons:20231016T101500", Toki{}, true},
+		13: {LayoutICalendar, "TZID=America/New_York", Toki{}, true},
+		14: {LayoutICalendarDate, "20231016T101500", Toki{}, true},
+	}
+
+	for i, tt := range tests {
+		got, err := Parse(tt.layout, tt.value, tt.layout)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("#%d:: Parse(%s, %q) error = %v, wantErr %t", i, tt.layout, tt.value, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if !got.Equal(tt.want) || got.Location().String() != tt.want.Location().String() || got.GetLayout() != tt.layout {
+			t.Errorf("#%d:: Parse(%s, %q) = %v, want %v", i, tt.layout, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseICalendarTZID(t *testing.T) {
+	got, err := ParseICalendar("20231016T101500", "Asia/Tokyo", LayoutICalendar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "TZID=Asia/Tokyo:20231016T101500"; got.Format(LayoutICalendar) != want || got.GetLayout() != LayoutICalendar {
+		t.Errorf("ParseICalendar() = %v, want %s", got, want)
+	}
+	if _, err := ParseICalendar("20231016T101500", "Nowhere/Special"); err == nil {
+		t.Error("ParseICalendar() with an unknown TZID succeeded")
+	}
+}
+
+func TestParseICalendarInLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := [...]struct {
+		value string
+		loc   *Location
+		want  Toki
+	}{
+		0: {"20231016T101500", ny, Date(2023, October, 16, 10, 15, 0, 0, ny)},
+		1: {"20231016T101500", UTC, Date(2023, October, 16, 10, 15, 0, 0, UTC)},
+		// Local has no TZID to give to ParseICalendar.
+		2: {"20231016T101500", time.Local, Date(2023, October, 16, 10, 15, 0, 0, time.Local)},
+		3: {"20231016", ny, Date(2023, October, 16, 0, 0, 0, 0, ny)},
+		// UTC values ignore loc.
+		4: {"20231016T101500Z", ny, Date(2023, October, 16, 10, 15, 0, 0, UTC)},
+		5: {"20230312T023000", ny, Date(2023, March, 12, 3, 30, 0, 0, ny)},
+	}
+
+	for i, tt := range tests {
+		got, err := ParseICalendarInLocation(tt.value, tt.loc, LayoutICalendar)
+		if err != nil || !got.Equal(tt.want) || got.Location() != tt.want.Location() || got.GetLayout() != LayoutICalendar {
+			t.Errorf("#%d:: ParseICalendarInLocation(%q, %v) = %v, %v, want %v", i, tt.value, tt.loc, got, err, tt.want)
+		}
+	}
+}
+
+func TestLayoutICalendarFloating(t *testing.T) {
+	v := Date(2023, October, 16, 10, 15, 0, 0, FixedZone("", 9*60*60))
+	if got, want := v.Format(LayoutICalendarFloating), "20231016T101500"; got != want {
+		t.Errorf("Format(LayoutICalendarFloating) = %q, want %q", got, want)
+	}
+}
+
+func TestICalendarMarshal(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type form struct {
+		Start Toki `json:"start"`
+	}
+
+	v := form{Start: Date(2023, October, 16, 10, 15, 0, 0, tokyo, LayoutICalendar)}
+	b, err := json.Marshal(v)
+	if want := `{"start":"TZID=Asia/Tokyo:20231016T101500"}`; err != nil || string(b) != want {
+		t.Fatalf("json.Marshal() = %s, %v, want %s", b, err, want)
+	}
+
+	got := form{Start: New(LayoutICalendar)}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Start.Equal(v.Start) || got.Start.Location().String() != "Asia/Tokyo" {
+		t.Errorf("json.Unmarshal() = %v, want %v", got.Start, v.Start)
+	}
+}
@@ -0,0 +1,31 @@
+package toki
+
+import "time"
+
+// A layoutCodec formats and parses the values of a layout that the time
+// package cannot express. format returns its best-effort text even when
+// it reports an error, for the sake of Format, which cannot fail.
+type layoutCodec struct {
+	format func(t time.Time) (string, error)
+	parse  func(value string) (time.Time, error)
+}
+
+// layoutCodecs holds the codecs of the layouts defined by this package.
+// It is only written during package initialization.
+var layoutCodecs = map[string]layoutCodec{}
+
+// lookupLayout returns the codec of layout, if it is not a layout of the
+// time package.
+func lookupLayout(layout string) (layoutCodec, bool) {
+	if c, ok := layoutCodecs[layout]; ok {
+		return c, true
+	}
+	if tag, rest, ok := splitLocaleLayout(layout); ok {
+		// Other bracketed layouts, like "[02/Jan/2006:15:04:05 -0700]",
+		// are layouts of the time package.
+		if l, err := LoadLocale(tag); err == nil {
+			return localeCodec(l, rest), true
+		}
+	}
+	return layoutCodec{}, false
+}
@@ -0,0 +1,374 @@
+package toki
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Locale holds the names of months, weekdays and halves of the day in a
+// language, used by FormatLocale and ParseLocale in place of the English
+// names of the time package.
+type Locale struct {
+	Tag           string     `json:"tag"`
+	Months        [12]string `json:"months"`
+	ShortMonths   [12]string `json:"short_months"`
+	Weekdays      [7]string  `json:"weekdays"`
+	ShortWeekdays [7]string  `json:"short_weekdays"`
+	AM            string     `json:"am"`
+	PM            string     `json:"pm"`
+}
+
+//go:embed data/locales.json
+var localesJSON []byte
+
+var (
+	localesOnce sync.Once
+	locales     map[string]Locale
+)
+
+func loadLocales() map[string]Locale {
+	localesOnce.Do(func() {
+		var data []Locale
+		if err := json.Unmarshal(localesJSON, &data); err != nil {
+			panic("toki: malformed locales.json: " + err.Error())
+		}
+		locales = make(map[string]Locale, len(data))
+		for _, l := range data {
+			locales[l.Tag] = l
+		}
+	})
+	return locales
+}
+
+// LoadLocale returns the built-in Locale of the BCP 47 language tag, one
+// of en, ja, de, fr, es and zh. A tag with a region or script, such as
+// de-AT, falls back to its language.
+func LoadLocale(tag string) (*Locale, error) {
+	t := strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	ls := loadLocales()
+	if l, ok := ls[t]; ok {
+		return &l, nil
+	}
+	if i := strings.IndexByte(t, '-'); i > 0 {
+		if l, ok := ls[t[:i]]; ok {
+			return &l, nil
+		}
+	}
+	return nil, fmt.Errorf("toki: unknown locale %q", tag)
+}
+
+// LocaleLayout returns a layout for a Toki that formats and parses like
+// layout with the names of the built-in locale tag, for use in JSON and
+// text marshalling. It takes the form [tag]layout. A bracketed layout
+// whose tag is not a built-in locale is a layout of the time package.
+func LocaleLayout(tag, layout string) string {
+	return "[" + tag + "]" + layout
+}
+
+// splitLocaleLayout splits a layout returned by LocaleLayout.
+func splitLocaleLayout(layout string) (tag, rest string, ok bool) {
+	if !strings.HasPrefix(layout, "[") {
+		return "", "", false
+	}
+	i := strings.IndexByte(layout, ']')
+	if i < 0 {
+		return "", "", false
+	}
+	return layout[1:i], layout[i+1:], true
+}
+
+func localeCodec(l *Locale, layout string) layoutCodec {
+	return layoutCodec{
+		format: func(t time.Time) (string, error) {
+			return Toki{Time: t}.FormatLocale(layout, l), nil
+		},
+		parse: func(value string) (time.Time, error) {
+			t, err := ParseLocale(layout, value, l)
+			return t.Time, err
+		},
+	}
+}
+
+// A chunkKind is the kind of a layout element.
+type chunkKind int
+
+const (
+	chunkNone chunkKind = iota
+	chunkLongMonth
+	chunkMonth
+	chunkLongWeekday
+	chunkWeekday
+	chunkPM
+	chunkLowerPM
+	chunkStd // any other element of the time package
+)
+
+// nextChunk splits layout around its first element, recognized like the
+// time package does.
+func nextChunk(layout string) (prefix, elem string, kind chunkKind, suffix string) {
+	split := func(i, n int, k chunkKind) (string, string, chunkKind, string) {
+		return layout[:i], layout[i : i+n], k, layout[i+n:]
+	}
+	for i := 0; i < len(layout); i++ {
+		rest := layout[i:]
+		switch c := layout[i]; c {
+		case 'J':
+			if strings.HasPrefix(rest, "January") {
+				return split(i, 7, chunkLongMonth)
+			}
+			if strings.HasPrefix(rest, "Jan") {
+				return split(i, 3, chunkMonth)
+			}
+		case 'M':
+			if strings.HasPrefix(rest, "Monday") {
+				return split(i, 6, chunkLongWeekday)
+			}
+			if strings.HasPrefix(rest, "Mon") {
+				return split(i, 3, chunkWeekday)
+			}
+			if strings.HasPrefix(rest, "MST") {
+				return split(i, 3, chunkStd)
+			}
+		case '0':
+			if len(rest) >= 2 && '1' <= rest[1] && rest[1] <= '6' {
+				return split(i, 2, chunkStd)
+			}
+			if strings.HasPrefix(rest, "002") {
+				return split(i, 3, chunkStd)
+			}
+		case '1':
+			if strings.HasPrefix(rest, "15") {
+				return split(i, 2, chunkStd)
+			}
+			return split(i, 1, chunkStd)
+		case '2':
+			if strings.HasPrefix(rest, "2006") {
+				return split(i, 4, chunkStd)
+			}
+			return split(i, 1, chunkStd)
+		case '_':
+			if strings.HasPrefix(rest, "_2") {
+				if strings.HasPrefix(rest, "_2006") {
+					return split(i+1, 4, chunkStd)
+				}
+				return split(i, 2, chunkStd)
+			}
+			if strings.HasPrefix(rest, "__2") {
+				return split(i, 3, chunkStd)
+			}
+		case '3', '4', '5':
+			return split(i, 1, chunkStd)
+		case 'P':
+			if strings.HasPrefix(rest, "PM") {
+				return split(i, 2, chunkPM)
+			}
+		case 'p':
+			if strings.HasPrefix(rest, "pm") {
+				return split(i, 2, chunkLowerPM)
+			}
+		case '-', 'Z':
+			for _, z := range []string{"070000", "07:00:00", "0700", "07:00", "07"} {
+				if strings.HasPrefix(rest[1:], z) {
+					return split(i, 1+len(z), chunkStd)
+				}
+			}
+		case '.', ',':
+			if len(rest) >= 2 && (rest[1] == '0' || rest[1] == '9') {
+				j := 1
+				for j < len(rest) && rest[j] == rest[1] {
+					j++
+				}
+				if j == len(rest) || rest[j] < '0' || rest[j] > '9' {
+					return split(i, j, chunkStd)
+				}
+			}
+		}
+	}
+	return layout, "", chunkNone, ""
+}
+
+// names returns the localized names of a chunk kind and their English
+// counterparts.
+func (l *Locale) names(kind chunkKind) (local, english []string) {
+	switch kind {
+	case chunkLongMonth:
+		return l.Months[:], longMonthNames
+	case chunkMonth:
+		return l.ShortMonths[:], shortMonthNames
+	case chunkLongWeekday:
+		return l.Weekdays[:], longWeekdayNames
+	case chunkWeekday:
+		return l.ShortWeekdays[:], shortWeekdayNames
+	case chunkPM:
+		return []string{l.AM, l.PM}, []string{"AM", "PM"}
+	case chunkLowerPM:
+		return []string{strings.ToLower(l.AM), strings.ToLower(l.PM)}, []string{"am", "pm"}
+	}
+	return nil, nil
+}
+
+var (
+	longMonthNames    = englishNames(12, func(i int) string { return Month(i + 1).String() })
+	shortMonthNames   = englishNames(12, func(i int) string { return Month(i + 1).String()[:3] })
+	longWeekdayNames  = englishNames(7, func(i int) string { return Weekday(i).String() })
+	shortWeekdayNames = englishNames(7, func(i int) string { return Weekday(i).String()[:3] })
+)
+
+func englishNames(n int, name func(int) string) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = name(i)
+	}
+	return names
+}
+
+// FormatLocale returns t formatted like Format, with the names of months,
+// weekdays and halves of the day (January, Jan, Monday, Mon, PM and pm)
+// taken from l.
+func (t Toki) FormatLocale(layout string, l *Locale) string {
+	var b strings.Builder
+	for layout != "" {
+		prefix, elem, kind, suffix := nextChunk(layout)
+		b.WriteString(prefix)
+		layout = suffix
+		switch kind {
+		case chunkNone:
+		case chunkStd:
+			b.WriteString(t.Time.Format(elem))
+		default:
+			local, _ := l.names(kind)
+			var i int
+			switch kind {
+			case chunkLongMonth, chunkMonth:
+				i = int(t.Month()) - 1
+			case chunkLongWeekday, chunkWeekday:
+				i = int(t.Weekday())
+			default:
+				if t.Hour() >= 12 {
+					i = 1
+				}
+			}
+			b.WriteString(local[i])
+		}
+	}
+	return b.String()
+}
+
+// ParseLocale parses value like Parse, recognizing the names of months,
+// weekdays and halves of the day of l instead of the English ones.
+func ParseLocale(layout, value string, l *Locale, layouts ...string) (Toki, error) {
+	english, err := delocalize(layout, value, l)
+	if err != nil {
+		return Toki{layout: setLayout(layouts...)}, err
+	}
+	return Parse(layout, english, layouts...)
+}
+
+// delocalize replaces the localized names in value, formatted in layout
+// with l, with the English names the time package parses.
+func delocalize(layout, value string, l *Locale) (string, error) {
+	var b strings.Builder
+	orig, origLayout := value, layout
+	bad := func() (string, error) {
+		return "", fmt.Errorf("toki: cannot parse %q as %q in locale %s", orig, origLayout, l.Tag)
+	}
+	for layout != "" {
+		prefix, elem, kind, suffix := nextChunk(layout)
+		layout = suffix
+		if !strings.HasPrefix(value, prefix) {
+			return bad()
+		}
+		b.WriteString(prefix)
+		value = value[len(prefix):]
+
+		switch kind {
+		case chunkNone:
+		case chunkStd:
+			n := stdWidth(elem, value)
+			b.WriteString(value[:n])
+			value = value[n:]
+		default:
+			local, english := l.names(kind)
+			best := -1
+			for i, name := range local {
+				if name != "" && len(name) <= len(value) && strings.EqualFold(value[:len(name)], name) &&
+					(best < 0 || len(name) > len(local[best])) {
+					best = i
+				}
+			}
+			if best < 0 {
+				return bad()
+			}
+			b.WriteString(english[best])
+			value = value[len(local[best]):]
+		}
+	}
+	b.WriteString(value)
+	return b.String(), nil
+}
+
+// stdWidth returns the length of the text at the start of value that
+// elem, an element of the time package, would parse. It is only used to
+// skip the text, leaving its validation to the time package.
+func stdWidth(elem, value string) int {
+	digits := func(s string, max int) int {
+		n := 0
+		for n < len(s) && n < max && '0' <= s[n] && s[n] <= '9' {
+			n++
+		}
+		return n
+	}
+	switch elem {
+	case "2006":
+		return digits(value, 4)
+	case "002":
+		return digits(value, 3)
+	case "_2", "__2":
+		n := 0
+		for n < len(value) && value[n] == ' ' {
+			n++
+		}
+		return n + digits(value[n:], len(elem)-n)
+	case "MST":
+		n := 0
+		for n < len(value) {
+			c := value[n]
+			if !('A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '+' || c == '-') {
+				break
+			}
+			n++
+		}
+		return n
+	}
+	switch elem[0] {
+	case '0':
+		return digits(value, 2)
+	case '1', '2', '3', '4', '5':
+		return digits(value, 2)
+	case 'Z':
+		if strings.HasPrefix(value, "Z") {
+			return 1
+		}
+		fallthrough
+	case '-':
+		if len(value) < len(elem) {
+			return len(value)
+		}
+		return len(elem)
+	case '.', ',':
+		if elem[1] == '0' {
+			if len(value) < len(elem) {
+				return len(value)
+			}
+			return len(elem)
+		}
+		if len(value) > 1 && (value[0] == '.' || value[0] == ',') {
+			return 1 + digits(value[1:], len(value))
+		}
+	}
+	return 0
+}
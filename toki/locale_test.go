@@ -0,0 +1,152 @@
+package toki
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func mustLoadLocale(t *testing.T, tag string) *Locale {
+	t.Helper()
+	l, err := LoadLocale(tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestLoadLocale(t *testing.T) {
+	for _, tag := range []string{"en", "ja", "de", "fr", "es", "zh", "de-AT", "zh_Hans_CN", "JA"} {
+		if _, err := LoadLocale(tag); err != nil {
+			t.Errorf("LoadLocale(%q) error = %v", tag, err)
+		}
+	}
+	if _, err := LoadLocale("xx"); err == nil {
+		t.Errorf("LoadLocale(xx) error = nil")
+	}
+
+	// Locales are copies.
+	l := mustLoadLocale(t, "fr")
+	l.Months[0] = "x"
+	if mustLoadLocale(t, "fr").Months[0] != "janvier" {
+		t.Errorf("LoadLocale returned a shared Locale")
+	}
+}
+
+func TestFormatParseLocale(t *testing.T) {
+	// Wednesday, March 1, 2023, in the afternoon.
+	v := Date(2023, March, 1, 15, 4, 5, 0, UTC)
+
+	tests := [...]struct {
+		tag    string
+		layout string
+		want   string
+	}{
+		0:  {"en", "Monday, January 2, 2006 3:04 PM", "Wednesday, March 1, 2023 3:04 PM"},
+		1:  {"ja", "2006年January2日(Mon) PM3時04分", "2023年3月1日(水) 午後3時04分"},
+		2:  {"ja", "Monday 2006-01-02", "水曜日 2023-03-01"},
+		3:  {"de", "Monday, 2. January 2006", "Mittwoch, 1. März 2023"},
+		4:  {"de", "Mon, 02. Jan 2006 15:04:05 MST", "Mi., 01. März 2023 15:04:05 UTC"},
+		5:  {"fr", "Monday 2 January 2006", "mercredi 1 mars 2023"},
+		6:  {"fr", "Mon _2 Jan 2006", "mer.  1 mars 2023"},
+		7:  {"es", "Monday, 2 de January de 2006, 3:04 pm", "miércoles, 1 de marzo de 2023, 3:04 p. m."},
+		8:  {"es", "Mon Jan 2 2006", "mié mar 1 2023"},
+		9:  {"zh", "2006年January2日 Monday PM3:04", "2023年三月1日 星期三 下午3:04"},
+		10: {"zh", "2006年Jan2日 Mon", "2023年3月1日 周三"},
+		11: {"de", "02.01.2006 15:04:05.000 -07:00", "01.03.2023 15:04:05.000 +00:00"},
+	}
+
+	for i, tt := range tests {
+		l := mustLoadLocale(t, tt.tag)
+		got := v.FormatLocale(tt.layout, l)
+		if got != tt.want {
+			t.Errorf("#%d:: FormatLocale(%q, %s) = %q, want %q", i, tt.layout, tt.tag, got, tt.want)
+			continue
+		}
+		p, err := ParseLocale(tt.layout, got, l)
+		if err != nil {
+			t.Errorf("#%d:: ParseLocale(%q, %q, %s) error = %v", i, tt.layout, got, tt.tag, err)
+			continue
+		}
+		if p.Format(tt.layout) != v.Format(tt.layout) {
+			t.Errorf("#%d:: ParseLocale(%q, %q, %s) = %v, want %v", i, tt.layout, got, tt.tag, p, v)
+		}
+	}
+}
+
+func TestParseLocaleErrors(t *testing.T) {
+	de := mustLoadLocale(t, "de")
+	tests := [...]struct {
+		layout string
+		value  string
+	}{
+		0: {"2 January 2006", "1 Marz 2023"},
+		1: {"2 January 2006", "1 March 2023"},
+		2: {"Jan 2, 2006", "März 1 2023"},
+		3: {"2 January 2006", "32 März 2023"},
+	}
+	for i, tt := range tests {
+		if _, err := ParseLocale(tt.layout, tt.value, de); err == nil {
+			t.Errorf("#%d:: ParseLocale(%q, %q) error = nil", i, tt.layout, tt.value)
+		}
+	}
+
+	// Names are matched without regard to case.
+	got, err := ParseLocale("2 January 2006", "1 MÄRZ 2023", de)
+	if err != nil || !got.Equal(Date(2023, March, 1, 0, 0, 0, 0, UTC)) {
+		t.Errorf("ParseLocale() = %v, %v", got, err)
+	}
+}
+
+func TestLocaleLayout(t *testing.T) {
+	layout := LocaleLayout("fr", "Monday 2 January 2006")
+	if layout != "[fr]Monday 2 January 2006" {
+		t.Errorf("LocaleLayout() = %q", layout)
+	}
+
+	v := Date(2023, October, 16, 0, 0, 0, 0, UTC, layout)
+	if got := v.Format(layout); got != "lundi 16 octobre 2023" {
+		t.Errorf("Format() = %q", got)
+	}
+	b, err := json.Marshal(v)
+	if err != nil || string(b) != `"lundi 16 octobre 2023"` {
+		t.Errorf("json.Marshal() = %s, %v", b, err)
+	}
+	u := New(layout)
+	if err := json.Unmarshal(b, &u); err != nil || !u.Equal(v) {
+		t.Errorf("json.Unmarshal(%s) = %v, %v", b, u, err)
+	}
+	p, err := Parse(layout, "lundi 16 octobre 2023")
+	if err != nil || !p.Equal(v) {
+		t.Errorf("Parse() = %v, %v", p, err)
+	}
+}
+
+func TestBracketedLayout(t *testing.T) {
+	// Bracketed layouts without a built-in locale are time layouts.
+	tests := [...]struct {
+		layout string
+	}{
+		0: {"[02/Jan/2006:15:04:05 -0700]"},
+		1: {"[2006-01-02] 15:04"},
+		2: {LocaleLayout("xx", time.RFC1123Z)},
+		3: {"[]" + time.RFC3339},
+	}
+
+	v := Date(2023, October, 16, 10, 15, 0, 0, FixedZone("", -7*60*60))
+	for i, tt := range tests {
+		want := v.Time.Format(tt.layout)
+		if got := v.Format(tt.layout); got != want {
+			t.Errorf("#%d:: Format(%q) = %q, want %q", i, tt.layout, got, want)
+		}
+		wantT, wantErr := time.Parse(tt.layout, want)
+		got, err := Parse(tt.layout, want)
+		if err != nil || wantErr != nil || !got.Equal(Toki{Time: wantT}) {
+			t.Errorf("#%d:: Parse(%q, %q) = %v, %v, want %v, %v", i, tt.layout, want, got, err, wantT, wantErr)
+		}
+		b, err := json.Marshal(Date(2023, October, 16, 10, 15, 0, 0, v.Location(), tt.layout))
+		if w, _ := json.Marshal(want); err != nil || string(b) != string(w) {
+			t.Errorf("#%d:: json.Marshal() = %s, %v, want %s", i, b, err, w)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package toki
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// locationHolder wraps the default location so that a nil location,
+// meaning time.Local, can be stored in an atomic.Value.
+type locationHolder struct {
+	loc *Location
+}
+
+var defaultLocation atomic.Value
+
+func init() {
+	defaultLocation.Store(locationHolder{})
+}
+
+// DefaultLocation returns the Location used by Local, Now and the Unix
+// functions. It is time.Local unless replaced with SetDefaultLocation.
+func DefaultLocation() *Location {
+	if loc := defaultLocation.Load().(locationHolder).loc; loc != nil {
+		return loc
+	}
+	return time.Local
+}
+
+// SetDefaultLocation replaces the Location used by Local, Now and the Unix
+// functions without touching time.Local, and returns a function that
+// restores the previous one. A nil loc restores time.Local.
+//
+// Tests should prefer tokitest.WithLocation, which is safe to use from
+// parallel tests.
+func SetDefaultLocation(loc *Location) (restore func()) {
+	prev := defaultLocation.Swap(locationHolder{loc})
+	return func() {
+		defaultLocation.Store(prev)
+	}
+}
+
+// inDefaultLocation returns t in the default location. t is returned
+// unchanged, keeping its monotonic clock reading, if no default location
+// has been set.
+func inDefaultLocation(t time.Time) time.Time {
+	if loc := defaultLocation.Load().(locationHolder).loc; loc != nil {
+		return t.In(loc)
+	}
+	return t
+}
+
+// localize re-interprets the location of t, decoded by the time package
+// against time.Local, against the default location: an offset matching
+// the default location is reported in it, and any other offset in an
+// unnamed fixed zone.
+func localize(t time.Time) time.Time {
+	loc := defaultLocation.Load().(locationHolder).loc
+	if loc == nil || loc == time.Local {
+		return t
+	}
+	if t.Location() != time.Local && t.Location().String() != "" {
+		return t
+	}
+	_, offset := t.Zone()
+	if _, off := t.In(loc).Zone(); off == offset {
+		return t.In(loc)
+	}
+	if t.Location() == time.Local {
+		return t.In(time.FixedZone("", offset))
+	}
+	return t
+}
@@ -0,0 +1,41 @@
+package toki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetDefaultLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := DefaultLocation()
+	sysLocal := time.Local
+
+	restore := SetDefaultLocation(tokyo)
+	if got := Unix(0, 0); got.Location() != tokyo || got.Hour() != 9 {
+		t.Errorf("Unix(0, 0) = %v, want 1970-01-01 09:00 in Asia/Tokyo", got)
+	}
+	if got := UnixMilli(0).Location(); got != tokyo {
+		t.Errorf("UnixMilli(0).Location() = %v, want %v", got, tokyo)
+	}
+	if got := Date(2023, October, 16, 0, 0, 0, 0, UTC).Local(); got.Location() != tokyo || got.Hour() != 9 {
+		t.Errorf("Local() = %v, want 09:00 in Asia/Tokyo", got)
+	}
+	// Offsets matching the default location decode into it.
+	if got, err := Parse(RFC3339, "2023-10-16T09:00:00+09:00"); err != nil || got.Location() != tokyo {
+		t.Errorf("Parse(+09:00).Location() = %v, %v, want %v", got.Location(), err, tokyo)
+	}
+	if got, err := Parse(RFC3339, "2023-10-16T09:00:00+08:00"); err != nil || got.Location() == tokyo {
+		t.Errorf("Parse(+08:00).Location() = %v, %v, want a fixed zone", got.Location(), err)
+	}
+	if time.Local != sysLocal {
+		t.Errorf("SetDefaultLocation modified time.Local")
+	}
+
+	restore()
+	if got := DefaultLocation(); got != prev {
+		t.Errorf("DefaultLocation() after restore = %v, want %v", got, prev)
+	}
+}
@@ -0,0 +1,423 @@
+package toki
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A NaturalError reports input that ParseNatural does not recognize.
+type NaturalError struct {
+	Input  string
+	Offset int // byte offset in Input of the offending word
+	Msg    string
+}
+
+func (e *NaturalError) Error() string {
+	return fmt.Sprintf("toki: cannot parse %q at offset %d: %s", e.Input, e.Offset, e.Msg)
+}
+
+// A Span is the range [Start, End) of bytes of a string.
+type Span struct {
+	Start, End int
+}
+
+// ParseNatural parses an English description of a date and time relative
+// to ref, read in loc, or in DefaultLocation if loc is nil. The input, case-insensitive, must match one of
+//
+//	now
+//	in N UNIT [TIME]        in 3 hours, in a week at noon
+//	N UNIT ago [TIME]       2 days ago, an hour ago
+//	DATE [TIME]             tomorrow 9am, next friday at noon
+//	TIME [DATE]             9:30pm tomorrow
+//
+// where
+//
+//	DATE is today, tomorrow, yesterday, an ISO date such as 2023-10-16,
+//	     a weekday such as friday or fri, optionally preceded by next,
+//	     last or this, or (first|last) day of [the|this|next|last] month;
+//	TIME is noon, midnight, H[:MM](am|pm) or HH:MM, optionally preceded
+//	     by at;
+//	UNIT is second, minute, hour, day, week, month or year, in singular
+//	     or plural, or an abbreviation such as sec, min, hr, h, d, w, mo
+//	     or y, and N is a number, a or an.
+//
+// A DATE without a TIME is at the start of the day, and a TIME without a
+// DATE is today. A bare weekday and one preceded by this are the first
+// such day from today, next is the first one after today, and last the
+// last one before today. Days and longer units keep the wall clock, as
+// AddPeriod does, while shorter units are absolute.
+//
+// The error, if any, is a *NaturalError locating the unrecognized word.
+func ParseNatural(input string, ref Toki, loc *Location) (Toki, error) {
+	toks := tokenizeNatural(input)
+	if len(toks) == 0 {
+		return Toki{}, &NaturalError{Input: input, Msg: "empty input"}
+	}
+	if loc == nil {
+		loc = DefaultLocation()
+	}
+	p := &naturalParser{input: input, toks: toks, ref: ref.In(loc), loc: loc}
+	t, err := p.parse()
+	if err != nil {
+		return Toki{}, err
+	}
+	if p.pos < len(toks) {
+		return Toki{}, p.errorf("unexpected %q", toks[p.pos].text)
+	}
+	return t, nil
+}
+
+// FindNatural finds the first description of a date and time in input,
+// such as "tomorrow 9am" in "call back tomorrow 9am please", and returns
+// it resolved as by ParseNatural along with its span in input.
+func FindNatural(input string, ref Toki, loc *Location) (Toki, Span, error) {
+	toks := tokenizeNatural(input)
+	if loc == nil {
+		loc = DefaultLocation()
+	}
+	for i := range toks {
+		p := &naturalParser{input: input, toks: toks, pos: i, ref: ref.In(loc), loc: loc}
+		if t, err := p.parse(); err == nil {
+			return t, Span{Start: toks[i].start, End: toks[p.pos-1].end}, nil
+		}
+	}
+	return Toki{}, Span{}, &NaturalError{Input: input, Msg: "no date or time found"}
+}
+
+type naturalToken struct {
+	text       string // lower-cased
+	start, end int
+}
+
+// tokenizeNatural splits input into words, numbers, which may contain
+// colons and hyphens, and single other characters. Spaces and commas
+// separate tokens.
+func tokenizeNatural(input string) []naturalToken {
+	var toks []naturalToken
+	for i := 0; i < len(input); {
+		r, n := utf8.DecodeRuneInString(input[i:])
+		start := i
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == ',':
+			i += n
+			continue
+		case unicode.IsLetter(r) || r == '\'':
+			for i < len(input) {
+				r, n := utf8.DecodeRuneInString(input[i:])
+				if !unicode.IsLetter(r) && r != '\'' {
+					break
+				}
+				i += n
+			}
+		case '0' <= r && r <= '9':
+			for i < len(input) && ('0' <= input[i] && input[i] <= '9' || input[i] == ':' || input[i] == '-') {
+				i++
+			}
+		default:
+			i += n
+		}
+		toks = append(toks, naturalToken{text: strings.ToLower(input[start:i]), start: start, end: i})
+	}
+	return toks
+}
+
+type naturalParser struct {
+	input string
+	toks  []naturalToken
+	pos   int
+	ref   Toki
+	loc   *Location
+}
+
+func (p *naturalParser) errorf(format string, args ...interface{}) error {
+	off := len(p.input)
+	if p.pos < len(p.toks) {
+		off = p.toks[p.pos].start
+	}
+	return &NaturalError{Input: p.input, Offset: off, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *naturalParser) peek(i int) string {
+	if p.pos+i < len(p.toks) {
+		return p.toks[p.pos+i].text
+	}
+	return ""
+}
+
+func (p *naturalParser) accept(words ...string) bool {
+	for i, w := range words {
+		if p.peek(i) != w {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *naturalParser) parse() (Toki, error) {
+	if p.accept("now") {
+		return p.ref, nil
+	}
+
+	t, isRelative, err := p.parseRelative()
+	if err != nil {
+		return Toki{}, err
+	}
+	if isRelative {
+		td, hasTime, err := p.parseTime()
+		if err != nil {
+			return Toki{}, err
+		}
+		if hasTime {
+			t = td.On(CivilDateOf(t), p.loc, t.layout)
+		}
+		return t, nil
+	}
+
+	date, hasDate, err := p.parseDate()
+	if err != nil {
+		return Toki{}, err
+	}
+	td, hasTime, err := p.parseTime()
+	if err != nil {
+		return Toki{}, err
+	}
+	if !hasDate && hasTime {
+		if date, hasDate, err = p.parseDate(); err != nil {
+			return Toki{}, err
+		}
+	}
+	switch {
+	case !hasDate && !hasTime:
+		if p.pos < len(p.toks) {
+			return Toki{}, p.errorf("unknown word %q", p.toks[p.pos].text)
+		}
+		return Toki{}, p.errorf("expected a date or time")
+	case !hasDate:
+		date = CivilDateOf(p.ref)
+	case !hasTime:
+		return date.In(p.loc, p.ref.layout), nil
+	}
+	return td.On(date, p.loc, p.ref.layout), nil
+}
+
+// parseRelative parses "in N UNIT" and "N UNIT ago".
+func (p *naturalParser) parseRelative() (Toki, bool, error) {
+	sign := 1
+	if p.peek(0) == "in" {
+		p.pos++
+	} else if _, ok := parseNaturalCount(p.peek(0)); !ok || !isNaturalUnit(p.peek(1)) {
+		return Toki{}, false, nil
+	} else {
+		sign = -1
+	}
+
+	n, ok := parseNaturalCount(p.peek(0))
+	if !ok {
+		return Toki{}, false, p.errorf("expected a number")
+	}
+	p.pos++
+	unit := p.peek(0)
+	if !isNaturalUnit(unit) {
+		return Toki{}, false, p.errorf("expected a unit of time")
+	}
+	p.pos++
+	if sign < 0 && !p.accept("ago") {
+		return Toki{}, false, p.errorf("expected \"ago\"")
+	}
+	return addNatural(p.ref, sign*n, naturalUnits[unit]), true, nil
+}
+
+func parseNaturalCount(s string) (int, bool) {
+	if s == "a" || s == "an" {
+		return 1, true
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n >= 0
+}
+
+var naturalUnits = map[string]Unit{
+	"second": UnitSecond, "seconds": UnitSecond, "sec": UnitSecond, "secs": UnitSecond, "s": UnitSecond,
+	"minute": UnitMinute, "minutes": UnitMinute, "min": UnitMinute, "mins": UnitMinute, "m": UnitMinute,
+	"hour": UnitHour, "hours": UnitHour, "hr": UnitHour, "hrs": UnitHour, "h": UnitHour,
+	"day": UnitDay, "days": UnitDay, "d": UnitDay,
+	"week": UnitWeek, "weeks": UnitWeek, "w": UnitWeek,
+	"month": UnitMonth, "months": UnitMonth, "mo": UnitMonth,
+	"year": UnitYear, "years": UnitYear, "y": UnitYear,
+}
+
+func isNaturalUnit(s string) bool {
+	_, ok := naturalUnits[s]
+	return ok
+}
+
+func addNatural(t Toki, n int, u Unit) Toki {
+	switch u {
+	case UnitDay:
+		return t.AddPeriod(Period{Days: n})
+	case UnitWeek:
+		return t.AddPeriod(Period{Days: 7 * n})
+	case UnitMonth:
+		return t.AddPeriod(Period{Months: n})
+	case UnitYear:
+		return t.AddPeriod(Period{Years: n})
+	}
+	return t.Add(time.Duration(n) * u.duration())
+}
+
+var naturalWeekdays = map[string]Weekday{
+	"sunday": Sunday, "sun": Sunday,
+	"monday": Monday, "mon": Monday,
+	"tuesday": Tuesday, "tue": Tuesday, "tues": Tuesday,
+	"wednesday": Wednesday, "wed": Wednesday,
+	"thursday": Thursday, "thu": Thursday, "thurs": Thursday,
+	"friday": Friday, "fri": Friday,
+	"saturday": Saturday, "sat": Saturday,
+}
+
+func (p *naturalParser) parseDate() (CivilDate, bool, error) {
+	today := CivilDateOf(p.ref)
+	switch w := p.peek(0); w {
+	case "today":
+		p.pos++
+		return today, true, nil
+	case "tomorrow":
+		p.pos++
+		return today.AddDays(1), true, nil
+	case "yesterday":
+		p.pos++
+		return today.AddDays(-1), true, nil
+	case "first", "last":
+		if p.peek(1) == "day" {
+			return p.parseDayOfMonth()
+		}
+		if w == "first" {
+			return CivilDate{}, false, nil
+		}
+		fallthrough
+	case "next", "this":
+		wd, ok := naturalWeekdays[p.peek(1)]
+		if !ok {
+			p.pos++
+			return CivilDate{}, false, p.errorf("expected a weekday")
+		}
+		p.pos += 2
+		diff := (int(wd) - int(today.Weekday()) + 7) % 7
+		switch w {
+		case "next":
+			if diff == 0 {
+				diff = 7
+			}
+		case "last":
+			diff -= 7
+		}
+		return today.AddDays(diff), true, nil
+	}
+	if wd, ok := naturalWeekdays[p.peek(0)]; ok {
+		p.pos++
+		return today.AddDays((int(wd) - int(today.Weekday()) + 7) % 7), true, nil
+	}
+	if s := p.peek(0); strings.Count(s, "-") == 2 {
+		d, err := ParseCivilDate(s)
+		if err != nil {
+			return CivilDate{}, false, p.errorf("invalid date %q", s)
+		}
+		p.pos++
+		return d, true, nil
+	}
+	return CivilDate{}, false, nil
+}
+
+// parseDayOfMonth parses "(first|last) day of [the|this|next|last] month".
+func (p *naturalParser) parseDayOfMonth() (CivilDate, bool, error) {
+	last := p.peek(0) == "last"
+	p.pos += 2
+	if !p.accept("of") {
+		return CivilDate{}, false, p.errorf("expected \"of\"")
+	}
+	ym := YearMonthOf(p.ref)
+	switch p.peek(0) {
+	case "the", "this":
+		p.pos++
+	case "next":
+		p.pos++
+		ym = ym.Next()
+	case "last":
+		p.pos++
+		ym = ym.Prev()
+	}
+	if !p.accept("month") {
+		return CivilDate{}, false, p.errorf("expected \"month\"")
+	}
+	if last {
+		return ym.LastDay(), true, nil
+	}
+	return ym.FirstDay(), true, nil
+}
+
+func (p *naturalParser) parseTime() (TimeOfDay, bool, error) {
+	at := p.accept("at")
+	switch p.peek(0) {
+	case "noon":
+		p.pos++
+		return TimeOfDay{Hour: 12}, true, nil
+	case "midnight":
+		p.pos++
+		return TimeOfDay{}, true, nil
+	}
+
+	s := p.peek(0)
+	if s == "" || s[0] < '0' || s[0] > '9' || strings.Contains(s, "-") {
+		if at {
+			return TimeOfDay{}, false, p.errorf("expected a time")
+		}
+		return TimeOfDay{}, false, nil
+	}
+	meridiem := p.peek(1)
+	if meridiem != "am" && meridiem != "pm" {
+		meridiem = ""
+	}
+	if meridiem == "" && !strings.Contains(s, ":") {
+		if at {
+			return TimeOfDay{}, false, p.errorf("expected a time")
+		}
+		return TimeOfDay{}, false, nil
+	}
+
+	var td TimeOfDay
+	hour, minute, ok := strings.Cut(s, ":")
+	h, err := strconv.Atoi(hour)
+	if err != nil {
+		return TimeOfDay{}, false, p.errorf("invalid time %q", s)
+	}
+	td.Hour = h
+	if ok {
+		if len(minute) != 2 {
+			return TimeOfDay{}, false, p.errorf("invalid time %q", s)
+		}
+		if td.Minute, err = strconv.Atoi(minute); err != nil {
+			return TimeOfDay{}, false, p.errorf("invalid time %q", s)
+		}
+	}
+	if meridiem != "" {
+		if td.Hour < 1 || td.Hour > 12 {
+			return TimeOfDay{}, false, p.errorf("hour %d out of range for %s", td.Hour, meridiem)
+		}
+		td.Hour %= 12
+		if meridiem == "pm" {
+			td.Hour += 12
+		}
+	}
+	if !td.IsValid() {
+		return TimeOfDay{}, false, p.errorf("invalid time %q", s)
+	}
+	p.pos++
+	if meridiem != "" {
+		p.pos++
+	}
+	return td, true, nil
+}
@@ -0,0 +1,153 @@
+package toki
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseNatural(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Monday, October 16, 2023, 14:30 in Los Angeles.
+	ref := Date(2023, October, 16, 14, 30, 0, 0, la, LayoutTimestamp)
+
+	tests := [...]struct {
+		input string
+		want  Toki
+	}{
+		0:  {"now", ref},
+		1:  {"today", Date(2023, October, 16, 0, 0, 0, 0, la)},
+		2:  {"Tomorrow 9am", Date(2023, October, 17, 9, 0, 0, 0, la)},
+		3:  {"9am tomorrow", Date(2023, October, 17, 9, 0, 0, 0, la)},
+		4:  {"yesterday at 9:30 pm", Date(2023, October, 15, 21, 30, 0, 0, la)},
+		5:  {"tomorrow at noon", Date(2023, October, 17, 12, 0, 0, 0, la)},
+		6:  {"midnight", Date(2023, October, 16, 0, 0, 0, 0, la)},
+		7:  {"12am", Date(2023, October, 16, 0, 0, 0, 0, la)},
+		8:  {"12pm", Date(2023, October, 16, 12, 0, 0, 0, la)},
+		9:  {"17:45", Date(2023, October, 16, 17, 45, 0, 0, la)},
+		10: {"next friday", Date(2023, October, 20, 0, 0, 0, 0, la)},
+		11: {"friday", Date(2023, October, 20, 0, 0, 0, 0, la)},
+		12: {"monday", Date(2023, October, 16, 0, 0, 0, 0, la)},
+		13: {"this monday", Date(2023, October, 16, 0, 0, 0, 0, la)},
+		14: {"next monday", Date(2023, October, 23, 0, 0, 0, 0, la)},
+		15: {"last monday", Date(2023, October, 9, 0, 0, 0, 0, la)},
+		16: {"last fri at 5pm", Date(2023, October, 13, 17, 0, 0, 0, la)},
+		17: {"in 3 hours", Date(2023, October, 16, 17, 30, 0, 0, la)},
+		18: {"in an hour", Date(2023, October, 16, 15, 30, 0, 0, la)},
+		19: {"2 days ago", Date(2023, October, 14, 14, 30, 0, 0, la)},
+		20: {"in 2 weeks at 9am", Date(2023, October, 30, 9, 0, 0, 0, la)},
+		21: {"in 1 month", Date(2023, November, 16, 14, 30, 0, 0, la)},
+		22: {"a year ago", Date(2022, October, 16, 14, 30, 0, 0, la)},
+		23: {"in 90 min", Date(2023, October, 16, 16, 0, 0, 0, la)},
+		24: {"last day of month", Date(2023, October, 31, 0, 0, 0, 0, la)},
+		25: {"last day of the month 6pm", Date(2023, October, 31, 18, 0, 0, 0, la)},
+		26: {"first day of next month", Date(2023, November, 1, 0, 0, 0, 0, la)},
+		27: {"last day of last month", Date(2023, September, 30, 0, 0, 0, 0, la)},
+		28: {"2023-12-25 8am", Date(2023, December, 25, 8, 0, 0, 0, la)},
+		// Across the end of DST, days keep the wall clock.
+		29: {"in 3 weeks", Date(2023, November, 6, 14, 30, 0, 0, la)},
+		30: {"in 504 hours", Date(2023, November, 6, 13, 30, 0, 0, la)},
+	}
+
+	for i, tt := range tests {
+		got, err := ParseNatural(tt.input, ref, la)
+		if err != nil {
+			t.Errorf("#%d:: ParseNatural(%q) error = %v", i, tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) || got.Location() != la || got.GetLayout() != LayoutTimestamp {
+			t.Errorf("#%d:: ParseNatural(%q) = %v, want %v", i, tt.input, got, tt.want)
+		}
+	}
+
+	// ref is read in loc.
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseNatural("today", ref, tokyo)
+	if want := Date(2023, October, 17, 0, 0, 0, 0, tokyo); err != nil || !got.Equal(want) {
+		t.Errorf("ParseNatural(today, Tokyo) = %v, %v, want %v", got, err, want)
+	}
+
+	// A nil loc is DefaultLocation.
+	defer SetDefaultLocation(tokyo)()
+	got, err = ParseNatural("today", ref, nil)
+	if want := Date(2023, October, 17, 0, 0, 0, 0, tokyo); err != nil || !got.Equal(want) || got.Location() != tokyo {
+		t.Errorf("ParseNatural(today, nil) = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestParseNaturalErrors(t *testing.T) {
+	ref := Date(2023, October, 16, 14, 30, 0, 0, UTC)
+
+	tests := [...]struct {
+		input  string
+		offset int
+	}{
+		0:  {"", 0},
+		1:  {"tomorow", 0},
+		2:  {"tomorrow 9am please", 13},
+		3:  {"next fryday", 5},
+		4:  {"in 3 parsecs", 5},
+		5:  {"in soon", 3},
+		6:  {"3 days", 6},
+		7:  {"13pm", 0},
+		8:  {"25:00", 0},
+		9:  {"tomorrow at", 11},
+		10: {"last day of year", 12},
+		11: {"2023-02-30", 0},
+		12: {"9", 0},
+	}
+
+	for i, tt := range tests {
+		_, err := ParseNatural(tt.input, ref, UTC)
+		var ne *NaturalError
+		if !errors.As(err, &ne) {
+			t.Errorf("#%d:: ParseNatural(%q) error = %v, want a *NaturalError", i, tt.input, err)
+			continue
+		}
+		if ne.Offset != tt.offset || ne.Input != tt.input {
+			t.Errorf("#%d:: ParseNatural(%q) error = %v, want offset %d", i, tt.input, err, tt.offset)
+		}
+	}
+}
+
+func TestFindNatural(t *testing.T) {
+	ref := Date(2023, October, 16, 14, 30, 0, 0, UTC)
+
+	tests := [...]struct {
+		input string
+		want  Toki
+		span  Span
+	}{
+		0: {"call back tomorrow 9am please", Date(2023, October, 17, 9, 0, 0, 0, UTC), Span{10, 22}},
+		1: {"deploy in 2 hours, then verify", Date(2023, October, 16, 16, 30, 0, 0, UTC), Span{7, 17}},
+		2: {"Meet me at 5pm.", Date(2023, October, 16, 17, 0, 0, 0, UTC), Span{8, 14}},
+		3: {"now", ref, Span{0, 3}},
+	}
+	for i, tt := range tests {
+		got, span, err := FindNatural(tt.input, ref, UTC)
+		if err != nil || !got.Equal(tt.want) || span != tt.span {
+			t.Errorf("#%d:: FindNatural(%q) = %v, %v, %v, want %v, %v", i, tt.input, got, span, err, tt.want, tt.span)
+		}
+	}
+
+	if _, _, err := FindNatural("nothing to see here", ref, UTC); err == nil {
+		t.Errorf("FindNatural() error = nil")
+	}
+
+	// A nil loc is DefaultLocation.
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer SetDefaultLocation(tokyo)()
+	got, span, err := FindNatural("call back tomorrow 9am please", ref, nil)
+	if want := Date(2023, October, 17, 9, 0, 0, 0, tokyo); err != nil || !got.Equal(want) || got.Location() != tokyo || span != (Span{10, 22}) {
+		t.Errorf("FindNatural(nil) = %v, %v, %v, want %v", got, span, err, want)
+	}
+}
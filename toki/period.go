@@ -0,0 +1,171 @@
+package toki
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Period is an amount of time in calendar and clock units, such as
+// 3 months and 2 days, whose absolute length depends on where it is
+// applied. Unlike time.Duration, it does not overflow past 292 years.
+type Period struct {
+	Years       int
+	Months      int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+// IsZero reports whether every field of p is zero.
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// Negate returns p with every field negated.
+func (p Period) Negate() Period {
+	return Period{
+		Years:       -p.Years,
+		Months:      -p.Months,
+		Days:        -p.Days,
+		Hours:       -p.Hours,
+		Minutes:     -p.Minutes,
+		Seconds:     -p.Seconds,
+		Nanoseconds: -p.Nanoseconds,
+	}
+}
+
+// clock returns the clock part of p as a Duration.
+func (p Period) clock() time.Duration {
+	return time.Duration(p.Hours)*time.Hour +
+		time.Duration(p.Minutes)*time.Minute +
+		time.Duration(p.Seconds)*time.Second +
+		time.Duration(p.Nanoseconds)
+}
+
+// String returns p in the ISO 8601 duration format, such as P3M2DT4H.
+// A period whose fields are all negative or zero is prefixed with a minus
+// sign; otherwise negative fields carry their own sign. The zero period
+// is formatted as PT0S.
+func (p Period) String() string {
+	if p.IsZero() {
+		return "PT0S"
+	}
+	var b strings.Builder
+	if p.Years <= 0 && p.Months <= 0 && p.Days <= 0 && p.Hours <= 0 &&
+		p.Minutes <= 0 && p.Seconds <= 0 && p.Nanoseconds <= 0 {
+		b.WriteByte('-')
+		p = p.Negate()
+	}
+	b.WriteByte('P')
+	field := func(v int, unit byte) {
+		if v != 0 {
+			b.WriteString(strconv.Itoa(v))
+			b.WriteByte(unit)
+		}
+	}
+	field(p.Years, 'Y')
+	field(p.Months, 'M')
+	field(p.Days, 'D')
+	if p.Hours != 0 || p.Minutes != 0 || p.Seconds != 0 || p.Nanoseconds != 0 {
+		b.WriteByte('T')
+		field(p.Hours, 'H')
+		field(p.Minutes, 'M')
+		if p.Seconds != 0 || p.Nanoseconds != 0 {
+			b.WriteString(formatSeconds(time.Duration(p.Seconds)*time.Second + time.Duration(p.Nanoseconds)))
+			b.WriteByte('S')
+		}
+	}
+	return b.String()
+}
+
+// formatSeconds formats d in seconds with a fraction without trailing
+// zeros.
+func formatSeconds(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	s := sign + strconv.FormatInt(int64(d/time.Second), 10)
+	if ns := d % time.Second; ns != 0 {
+		s += "." + strings.TrimRight(fmt.Sprintf("%09d", int64(ns)), "0")
+	}
+	return s
+}
+
+// AddPeriod returns t shifted by p: years and months first, clamped to
+// the end of the month as with AddMonthsClamped, then days on the wall
+// clock as with AddDate, and finally the clock part as an absolute
+// duration.
+func (t Toki) AddPeriod(p Period) Toki {
+	if p.Years != 0 || p.Months != 0 {
+		t = t.AddMonthsClamped(p.Years*12 + p.Months)
+	}
+	if p.Days != 0 {
+		t = t.AddDate(0, 0, p.Days)
+	}
+	return t.Add(p.clock())
+}
+
+// Diff returns the calendar difference t-u as a Period in u's location,
+// the largest whole years, months and days followed by the remaining
+// clock time. If t is after u, every field is positive or zero and
+// u.AddPeriod(t.Diff(u)) equals t. If t is before u, the result is
+// u.Diff(t) negated.
+func (t Toki) Diff(u Toki) Period {
+	if t.Before(u) {
+		return u.Diff(t).Negate()
+	}
+	loc := u.Location()
+	b := t.Time.In(loc)
+
+	uy, um, _ := u.Date()
+	by, bm, _ := b.Date()
+	months := (by-uy)*12 + int(bm-um)
+	mid := u.AddMonthsClamped(months).Time
+	for months > 0 && mid.After(b) {
+		months--
+		mid = u.AddMonthsClamped(months).Time
+	}
+
+	days := CivilDateOf(Toki{Time: b}).DaysSince(CivilDateOf(Toki{Time: mid}))
+	end := mid.AddDate(0, 0, days)
+	for days > 0 && end.After(b) {
+		days--
+		end = mid.AddDate(0, 0, days)
+	}
+
+	rest := b.Sub(end)
+	return Period{
+		Years:       months / 12,
+		Months:      months % 12,
+		Days:        days,
+		Hours:       int(rest / time.Hour),
+		Minutes:     int(rest % time.Hour / time.Minute),
+		Seconds:     int(rest % time.Minute / time.Second),
+		Nanoseconds: int(rest % time.Second),
+	}
+}
+
+// MonthsBetween returns the number of whole months from a to b, negative
+// if b is before a. See Diff.
+func MonthsBetween(a, b Toki) int {
+	p := b.Diff(a)
+	return p.Years*12 + p.Months
+}
+
+// DaysBetween returns the number of calendar days from the date of a to
+// the date of b in loc, regardless of the time of day and of transitions.
+func DaysBetween(a, b Toki, loc *Location) int {
+	return CivilDateOf(b.In(loc)).DaysSince(CivilDateOf(a.In(loc)))
+}
+
+// Age returns the number of whole years from t, such as a date of birth,
+// to at. A person born on February 29 gains a year on February 28 in
+// common years.
+func (t Toki) Age(at Toki) int {
+	return at.Diff(t).Years
+}
@@ -0,0 +1,135 @@
+package toki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodString(t *testing.T) {
+	tests := [...]struct {
+		p    Period
+		want string
+	}{
+		0: {Period{}, "PT0S"},
+		1: {Period{Months: 3, Days: 2}, "P3M2D"},
+		2: {Period{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}, "P1Y2M3DT4H5M6S"},
+		3: {Period{Seconds: 1, Nanoseconds: 500000000}, "PT1.5S"},
+		4: {Period{Nanoseconds: 1}, "PT0.000000001S"},
+		5: {Period{Months: -3, Days: -2}, "-P3M2D"},
+		6: {Period{Months: 1, Days: -2}, "P1M-2D"},
+		7: {Period{Years: 300}, "P300Y"},
+	}
+
+	for i, tt := range tests {
+		if got := tt.p.String(); got != tt.want {
+			t.Errorf("#%d:: %#v.String() = %q, want %q", i, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [...]struct {
+		t, u Toki
+		want Period
+	}{
+		0: {Date(2023, October, 16, 0, 0, 0, 0, UTC), Date(2023, October, 16, 0, 0, 0, 0, UTC), Period{}},
+		1: {Date(2023, April, 3, 0, 0, 0, 0, UTC), Date(2023, January, 1, 0, 0, 0, 0, UTC), Period{Months: 3, Days: 2}},
+		2: {Date(2023, January, 1, 0, 0, 0, 0, UTC), Date(2023, April, 3, 0, 0, 0, 0, UTC), Period{Months: -3, Days: -2}},
+		3: {Date(2023, October, 16, 12, 30, 15, 5, UTC), Date(2020, February, 29, 18, 0, 0, 0, UTC), Period{Years: 3, Months: 7, Days: 16, Hours: 18, Minutes: 30, Seconds: 15, Nanoseconds: 5}},
+		// One month after January 31 is February 28.
+		4: {Date(2023, February, 28, 0, 0, 0, 0, UTC), Date(2023, January, 31, 0, 0, 0, 0, UTC), Period{Months: 1}},
+		5: {Date(2023, March, 1, 0, 0, 0, 0, UTC), Date(2023, January, 31, 0, 0, 0, 0, UTC), Period{Months: 1, Days: 1}},
+		6: {Date(2023, March, 31, 0, 0, 0, 0, UTC), Date(2023, January, 31, 0, 0, 0, 0, UTC), Period{Months: 2}},
+		// Beyond the range of time.Duration.
+		7: {Date(2500, January, 1, 0, 0, 0, 0, UTC), Date(1900, January, 1, 0, 0, 0, 0, UTC), Period{Years: 600}},
+		// The day DST starts is 23 hours long, but still one day.
+		8: {Date(2023, March, 13, 0, 0, 0, 0, la), Date(2023, March, 12, 0, 0, 0, 0, la), Period{Days: 1}},
+		9: {Date(2023, March, 12, 12, 0, 0, 0, la), Date(2023, March, 12, 0, 0, 0, 0, la), Period{Hours: 11}},
+		// t is read in u's location.
+		10: {Date(2023, October, 16, 0, 0, 0, 0, UTC), Date(2023, October, 15, 0, 0, 0, 0, la), Period{Hours: 17}},
+		11: {Date(2023, October, 16, 8, 59, 0, 0, UTC), Date(2023, October, 15, 9, 0, 0, 0, UTC), Period{Hours: 23, Minutes: 59}},
+	}
+
+	for i, tt := range tests {
+		got := tt.t.Diff(tt.u)
+		if got != tt.want {
+			t.Errorf("#%d:: %v.Diff(%v) = %v, want %v", i, tt.t, tt.u, got, tt.want)
+		}
+		if back := tt.u.Diff(tt.t); back != got.Negate() {
+			t.Errorf("#%d:: %v.Diff(%v) = %v, want %v", i, tt.u, tt.t, back, got.Negate())
+		}
+		if !tt.t.Before(tt.u) {
+			if sum := tt.u.AddPeriod(got); !sum.Equal(tt.t) {
+				t.Errorf("#%d:: %v.AddPeriod(%v) = %v, want %v", i, tt.u, got, sum, tt.t)
+			}
+		}
+	}
+}
+
+func TestDiffRoundTrip(t *testing.T) {
+	base := Date(2020, January, 31, 13, 0, 0, 0, UTC)
+	for d := 0; d < 800; d += 7 {
+		for _, h := range []time.Duration{0, 5 * time.Hour, 15*time.Hour + time.Nanosecond} {
+			u := base.AddDate(0, 0, d).Add(h)
+			p := u.Diff(base)
+			if got := base.AddPeriod(p); !got.Equal(u) {
+				t.Fatalf("%v.AddPeriod(%v) = %v, want %v", base, p, got, u)
+			}
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := Date(2023, January, 31, 0, 0, 0, 0, UTC)
+	b := Date(2023, October, 16, 0, 0, 0, 0, UTC)
+	if got := MonthsBetween(a, b); got != 8 {
+		t.Errorf("MonthsBetween(%v, %v) = %d, want 8", a, b, got)
+	}
+	if got := MonthsBetween(b, a); got != -8 {
+		t.Errorf("MonthsBetween(%v, %v) = %d, want -8", b, a, got)
+	}
+
+	// 23:00 and 01:00 UTC are 2 hours apart, but on different days.
+	a = Date(2023, October, 15, 23, 0, 0, 0, UTC)
+	b = Date(2023, October, 16, 1, 0, 0, 0, UTC)
+	if got := DaysBetween(a, b, UTC); got != 1 {
+		t.Errorf("DaysBetween(UTC) = %d, want 1", got)
+	}
+	if got := DaysBetween(a, b, tokyo); got != 0 {
+		t.Errorf("DaysBetween(Tokyo) = %d, want 0", got)
+	}
+	if got := DaysBetween(b, a.AddDate(0, 0, -10), la); got != -10 {
+		t.Errorf("DaysBetween(LA) = %d, want -10", got)
+	}
+
+	ageTests := [...]struct {
+		birth, at Toki
+		want      int
+	}{
+		0: {Date(1990, October, 16, 0, 0, 0, 0, UTC), Date(2023, October, 15, 0, 0, 0, 0, UTC), 32},
+		1: {Date(1990, October, 16, 0, 0, 0, 0, UTC), Date(2023, October, 16, 0, 0, 0, 0, UTC), 33},
+		2: {Date(2000, February, 29, 0, 0, 0, 0, UTC), Date(2023, February, 27, 0, 0, 0, 0, UTC), 22},
+		3: {Date(2000, February, 29, 0, 0, 0, 0, UTC), Date(2023, February, 28, 0, 0, 0, 0, UTC), 23},
+		4: {Date(2000, February, 29, 0, 0, 0, 0, UTC), Date(2024, February, 29, 0, 0, 0, 0, UTC), 24},
+		5: {Date(2023, October, 16, 0, 0, 0, 0, UTC), Date(2020, October, 16, 0, 0, 0, 0, UTC), -3},
+	}
+	for i, tt := range ageTests {
+		if got := tt.birth.Age(tt.at); got != tt.want {
+			t.Errorf("#%d:: %v.Age(%v) = %d, want %d", i, tt.birth, tt.at, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package toki
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A Range is the half-open interval of time [Start, End). It is empty if
+// End is not after Start.
+type Range struct {
+	Start Toki
+	End   Toki
+}
+
+// NewRange returns the range [start, end).
+func NewRange(start, end Toki) Range {
+	return Range{Start: start, End: end}
+}
+
+// IsEmpty reports whether r contains no instant.
+func (r Range) IsEmpty() bool {
+	return !r.Start.Before(r.End)
+}
+
+// Duration returns the length of r, 0 if it is empty.
+func (r Range) Duration() time.Duration {
+	if r.IsEmpty() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// Contains reports whether t is in r.
+func (r Range) Contains(t Toki) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// ContainsRange reports whether every instant of s is in r. An empty
+// range is contained in any range.
+func (r Range) ContainsRange(s Range) bool {
+	if s.IsEmpty() {
+		return true
+	}
+	return !s.Start.Before(r.Start) && !s.End.After(r.End)
+}
+
+// Overlaps reports whether r and s have an instant in common. Adjacent
+// ranges, where one ends when the other starts, do not overlap.
+func (r Range) Overlaps(s Range) bool {
+	return !r.IsEmpty() && !s.IsEmpty() && r.Start.Before(s.End) && s.Start.Before(r.End)
+}
+
+// Intersect returns the instants common to r and s. It returns false if
+// they do not overlap.
+func (r Range) Intersect(s Range) (Range, bool) {
+	if !r.Overlaps(s) {
+		return Range{}, false
+	}
+	return Range{Start: laterOf(r.Start, s.Start), End: earlierOf(r.End, s.End)}, true
+}
+
+// Union returns the range covering r and s. It returns false if they
+// neither overlap nor are adjacent, as their union is then not a range.
+// The union with an empty range is the other range.
+func (r Range) Union(s Range) (Range, bool) {
+	switch {
+	case r.IsEmpty():
+		return s, true
+	case s.IsEmpty():
+		return r, true
+	case r.Start.After(s.End) || s.Start.After(r.End):
+		return Range{}, false
+	}
+	return Range{Start: earlierOf(r.Start, s.Start), End: laterOf(r.End, s.End)}, true
+}
+
+// Gap returns the range between r and s. It returns false if they overlap
+// or are adjacent, or if either is empty.
+func (r Range) Gap(s Range) (Range, bool) {
+	if r.IsEmpty() || s.IsEmpty() {
+		return Range{}, false
+	}
+	if s.Start.Before(r.Start) {
+		r, s = s, r
+	}
+	if !r.End.Before(s.Start) {
+		return Range{}, false
+	}
+	return Range{Start: r.End, End: s.Start}, true
+}
+
+// Split cuts r at the boundaries of the unit u in the location of
+// r.Start, as computed by StartOf, so that the first and last parts may
+// be shorter than a unit. Weeks start on weekStart, Monday by default.
+// The parts have the layout of r.Start, except for the end of the last
+// part, which is r.End.
+func (r Range) Split(u Unit, weekStart ...Weekday) []Range {
+	if r.IsEmpty() {
+		return nil
+	}
+	var parts []Range
+	cur := r.Start
+	for cur.Before(r.End) {
+		next := cur.EndOf(u, weekStart...).Add(time.Nanosecond)
+		if !next.Before(r.End) {
+			next = r.End
+		}
+		parts = append(parts, Range{Start: cur, End: next})
+		cur = next
+	}
+	return parts
+}
+
+func (r Range) String() string {
+	return "[" + r.Start.String() + ", " + r.End.String() + ")"
+}
+
+// MarshalJSON encodes r as an object with the members start and end, each
+// marshalled in the layout of its Toki.
+func (r Range) MarshalJSON() ([]byte, error) {
+	start, err := r.Start.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("Range.MarshalJSON: start: %w", err)
+	}
+	end, err := r.End.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("Range.MarshalJSON: end: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`{"start":`)
+	buf.Write(start)
+	buf.WriteString(`,"end":`)
+	buf.Write(end)
+	buf.WriteString(`}`)
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes an object with the members start and end, each in
+// the layout already set on r.Start and r.End.
+func (r *Range) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var v struct {
+		Start json.RawMessage `json:"start"`
+		End   json.RawMessage `json:"end"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("Range.UnmarshalJSON: %w", err)
+	}
+	if v.Start != nil {
+		if err := r.Start.UnmarshalJSON(v.Start); err != nil {
+			return fmt.Errorf("Range.UnmarshalJSON: start: %w", err)
+		}
+	}
+	if v.End != nil {
+		if err := r.End.UnmarshalJSON(v.End); err != nil {
+			return fmt.Errorf("Range.UnmarshalJSON: end: %w", err)
+		}
+	}
+	return nil
+}
+
+func earlierOf(t, u Toki) Toki {
+	if u.Before(t) {
+		return u
+	}
+	return t
+}
+
+func laterOf(t, u Toki) Toki {
+	if u.After(t) {
+		return u
+	}
+	return t
+}
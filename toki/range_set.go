@@ -0,0 +1,145 @@
+package toki
+
+import (
+	"sort"
+	"time"
+)
+
+// A RangeSet is a set of instants, held as sorted ranges that neither
+// overlap nor touch. The zero value is the empty set. Operations return new
+// sets and run in linear time, after the O(n log n) sort of NewRangeSet.
+type RangeSet struct {
+	ranges []Range
+}
+
+// NewRangeSet returns the set of the instants in any of ranges, merging
+// those that overlap or are adjacent. Empty ranges are dropped.
+func NewRangeSet(ranges ...Range) RangeSet {
+	rs := make([]Range, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			rs = append(rs, r)
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].Start.Before(rs[j].Start)
+	})
+	return RangeSet{ranges: coalesce(rs)}
+}
+
+// coalesce merges, in place, the overlapping and adjacent ranges of rs,
+// which are sorted by start.
+func coalesce(rs []Range) []Range {
+	if len(rs) == 0 {
+		return nil
+	}
+	out := rs[:1]
+	for _, r := range rs[1:] {
+		last := &out[len(out)-1]
+		if r.Start.After(last.End) {
+			out = append(out, r)
+		} else if r.End.After(last.End) {
+			last.End = r.End
+		}
+	}
+	return out
+}
+
+// Ranges returns the ranges of s in order.
+func (s RangeSet) Ranges() []Range {
+	return append([]Range(nil), s.ranges...)
+}
+
+// Len returns the number of ranges of s.
+func (s RangeSet) Len() int {
+	return len(s.ranges)
+}
+
+// IsEmpty reports whether s contains no instant.
+func (s RangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Duration returns the total length of the ranges of s.
+func (s RangeSet) Duration() time.Duration {
+	var d time.Duration
+	for _, r := range s.ranges {
+		d += r.Duration()
+	}
+	return d
+}
+
+// Contains reports whether t is in s.
+func (s RangeSet) Contains(t Toki) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		return t.Before(s.ranges[i].End)
+	})
+	return i < len(s.ranges) && s.ranges[i].Contains(t)
+}
+
+// Union returns the instants in s or in o.
+func (s RangeSet) Union(o RangeSet) RangeSet {
+	rs := make([]Range, 0, len(s.ranges)+len(o.ranges))
+	i, j := 0, 0
+	for i < len(s.ranges) || j < len(o.ranges) {
+		if j == len(o.ranges) || i < len(s.ranges) && !o.ranges[j].Start.Before(s.ranges[i].Start) {
+			rs = append(rs, s.ranges[i])
+			i++
+		} else {
+			rs = append(rs, o.ranges[j])
+			j++
+		}
+	}
+	return RangeSet{ranges: coalesce(rs)}
+}
+
+// Intersect returns the instants in both s and o.
+func (s RangeSet) Intersect(o RangeSet) RangeSet {
+	var rs []Range
+	i, j := 0, 0
+	for i < len(s.ranges) && j < len(o.ranges) {
+		a, b := s.ranges[i], o.ranges[j]
+		if r, ok := a.Intersect(b); ok {
+			rs = append(rs, r)
+		}
+		if a.End.Before(b.End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return RangeSet{ranges: rs}
+}
+
+// Difference returns the instants in s but not in o.
+func (s RangeSet) Difference(o RangeSet) RangeSet {
+	var rs []Range
+	j := 0
+	for _, a := range s.ranges {
+		start := a.Start
+		// Skip the ranges of o that end before a.
+		for j < len(o.ranges) && !o.ranges[j].End.After(start) {
+			j++
+		}
+		// The last range of o cut from a may extend over the next range
+		// of s, so j is left on it.
+		for k := j; k < len(o.ranges) && o.ranges[k].Start.Before(a.End); k++ {
+			b := o.ranges[k]
+			if b.Start.After(start) {
+				rs = append(rs, Range{Start: start, End: b.Start})
+			}
+			if b.End.After(start) {
+				start = b.End
+			}
+		}
+		if start.Before(a.End) {
+			rs = append(rs, Range{Start: start, End: a.End})
+		}
+	}
+	return RangeSet{ranges: rs}
+}
+
+// Complement returns the instants of within that are not in s.
+func (s RangeSet) Complement(within Range) RangeSet {
+	return NewRangeSet(within).Difference(s)
+}
@@ -0,0 +1,179 @@
+package toki
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+var rangeSetBase = Date(2023, October, 16, 0, 0, 0, 0, UTC)
+
+// minutes returns the range between minutes a and b after rangeSetBase.
+func minutes(a, b int) Range {
+	return NewRange(rangeSetBase.Add(time.Duration(a)*time.Minute), rangeSetBase.Add(time.Duration(b)*time.Minute))
+}
+
+func equalRanges(got RangeSet, want []Range) bool {
+	if got.Len() != len(want) {
+		return false
+	}
+	for i, r := range got.Ranges() {
+		if !r.Start.Equal(want[i].Start) || !r.End.Equal(want[i].End) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNewRangeSet(t *testing.T) {
+	tests := [...]struct {
+		ranges []Range
+		want   []Range
+	}{
+		0: {nil, nil},
+		1: {[]Range{minutes(10, 20), minutes(0, 5)}, []Range{minutes(0, 5), minutes(10, 20)}},
+		2: {[]Range{minutes(0, 10), minutes(5, 15), minutes(15, 20)}, []Range{minutes(0, 20)}},
+		3: {[]Range{minutes(0, 30), minutes(5, 10)}, []Range{minutes(0, 30)}},
+		4: {[]Range{minutes(5, 5), minutes(10, 0)}, nil},
+	}
+
+	for i, tt := range tests {
+		if got := NewRangeSet(tt.ranges...); !equalRanges(got, tt.want) {
+			t.Errorf("#%d:: NewRangeSet(%v) = %v, want %v", i, tt.ranges, got.Ranges(), tt.want)
+		}
+	}
+}
+
+func TestRangeSetOperations(t *testing.T) {
+	// Working hours minus meetings.
+	work := NewRangeSet(minutes(9*60, 12*60), minutes(13*60, 17*60))
+	busy := NewRangeSet(minutes(8*60, 9*60+30), minutes(11*60, 14*60), minutes(16*60, 16*60+30))
+
+	tests := [...]struct {
+		name string
+		got  RangeSet
+		want []Range
+	}{
+		0: {"Union", work.Union(busy), []Range{minutes(8*60, 17*60)}},
+		1: {"Intersect", work.Intersect(busy), []Range{
+			minutes(9*60, 9*60+30), minutes(11*60, 12*60), minutes(13*60, 14*60), minutes(16*60, 16*60+30),
+		}},
+		2: {"Difference", work.Difference(busy), []Range{
+			minutes(9*60+30, 11*60), minutes(14*60, 16*60), minutes(16*60+30, 17*60),
+		}},
+		3: {"Complement", work.Complement(minutes(0, 24*60)), []Range{
+			minutes(0, 9*60), minutes(12*60, 13*60), minutes(17*60, 24*60),
+		}},
+		4: {"Difference with empty", work.Difference(RangeSet{}), work.Ranges()},
+		5: {"Intersect with empty", work.Intersect(RangeSet{}), nil},
+	}
+
+	for i, tt := range tests {
+		if !equalRanges(tt.got, tt.want) {
+			t.Errorf("#%d:: %s = %v, want %v", i, tt.name, tt.got.Ranges(), tt.want)
+		}
+	}
+
+	if got, want := work.Duration(), 7*time.Hour; got != want {
+		t.Errorf("Duration() = %v, want %v", got, want)
+	}
+	for m, want := range map[int]bool{8 * 60: false, 9 * 60: true, 12 * 60: false, 16*60 + 59: true, 17 * 60: false} {
+		if got := work.Contains(rangeSetBase.Add(time.Duration(m) * time.Minute)); got != want {
+			t.Errorf("Contains(minute %d) = %v, want %v", m, got, want)
+		}
+	}
+}
+
+// TestRangeSetRandom checks the operations against sets of minutes.
+func TestRangeSetRandom(t *testing.T) {
+	const span = 200
+	rnd := rand.New(rand.NewSource(1))
+	randomSet := func() (RangeSet, [span]bool) {
+		var in [span]bool
+		var ranges []Range
+		for n := rnd.Intn(8); n > 0; n-- {
+			a := rnd.Intn(span)
+			b := a + rnd.Intn(span-a+1)
+			ranges = append(ranges, minutes(a, b))
+			for m := a; m < b; m++ {
+				in[m] = true
+			}
+		}
+		return NewRangeSet(ranges...), in
+	}
+	check := func(i int, name string, s RangeSet, want func(m int) bool) {
+		for m := 0; m < span; m++ {
+			if got := s.Contains(rangeSetBase.Add(time.Duration(m) * time.Minute)); got != want(m) {
+				t.Errorf("#%d:: %s contains minute %d = %v, want %v", i, name, m, got, want(m))
+				return
+			}
+		}
+		rs := s.Ranges()
+		for k := 1; k < len(rs); k++ {
+			if !rs[k-1].End.Before(rs[k].Start) {
+				t.Errorf("#%d:: %s = %v, not normalized", i, name, rs)
+				return
+			}
+		}
+	}
+
+	for i := 0; i < 500; i++ {
+		s, sin := randomSet()
+		o, oin := randomSet()
+		check(i, "Union", s.Union(o), func(m int) bool { return sin[m] || oin[m] })
+		check(i, "Intersect", s.Intersect(o), func(m int) bool { return sin[m] && oin[m] })
+		check(i, "Difference", s.Difference(o), func(m int) bool { return sin[m] && !oin[m] })
+		check(i, "Complement", s.Complement(minutes(50, 150)), func(m int) bool { return m >= 50 && m < 150 && !sin[m] })
+	}
+}
+
+func benchmarkRanges(n int, seed int64) []Range {
+	rnd := rand.New(rand.NewSource(seed))
+	ranges := make([]Range, n)
+	for i := range ranges {
+		a := rnd.Intn(10 * n)
+		ranges[i] = minutes(a, a+1+rnd.Intn(10))
+	}
+	return ranges
+}
+
+func BenchmarkNewRangeSet(b *testing.B) {
+	ranges := benchmarkRanges(100000, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewRangeSet(ranges...)
+	}
+}
+
+func BenchmarkRangeSetUnion(b *testing.B) {
+	s, o := NewRangeSet(benchmarkRanges(100000, 1)...), NewRangeSet(benchmarkRanges(100000, 2)...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Union(o)
+	}
+}
+
+func BenchmarkRangeSetIntersect(b *testing.B) {
+	s, o := NewRangeSet(benchmarkRanges(100000, 1)...), NewRangeSet(benchmarkRanges(100000, 2)...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Intersect(o)
+	}
+}
+
+func BenchmarkRangeSetDifference(b *testing.B) {
+	s, o := NewRangeSet(benchmarkRanges(100000, 1)...), NewRangeSet(benchmarkRanges(100000, 2)...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Difference(o)
+	}
+}
+
+func BenchmarkRangeSetComplement(b *testing.B) {
+	s := NewRangeSet(benchmarkRanges(100000, 1)...)
+	within := minutes(0, 1000000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Complement(within)
+	}
+}
@@ -0,0 +1,180 @@
+package toki
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func hm(h, m int) Toki {
+	return Date(2023, October, 16, h, m, 0, 0, UTC)
+}
+
+func TestRangeContains(t *testing.T) {
+	r := NewRange(hm(9, 0), hm(17, 0))
+	tests := [...]struct {
+		t    Toki
+		want bool
+	}{
+		0: {hm(8, 59), false},
+		1: {hm(9, 0), true},
+		2: {hm(12, 0), true},
+		3: {hm(16, 59), true},
+		4: {hm(17, 0), false},
+	}
+
+	for i, tt := range tests {
+		if got := r.Contains(tt.t); got != tt.want {
+			t.Errorf("#%d:: %v.Contains(%v) = %v, want %v", i, r, tt.t, got, tt.want)
+		}
+	}
+	if NewRange(hm(9, 0), hm(9, 0)).Contains(hm(9, 0)) {
+		t.Error("empty range contains its start")
+	}
+}
+
+func TestRangeOperations(t *testing.T) {
+	tests := [...]struct {
+		r, s      Range
+		overlaps  bool
+		intersect Range
+		union     Range
+		unionOK   bool
+		gap       Range
+		gapOK     bool
+	}{
+		// Overlapping.
+		0: {
+			NewRange(hm(9, 0), hm(12, 0)), NewRange(hm(11, 0), hm(13, 0)),
+			true, NewRange(hm(11, 0), hm(12, 0)),
+			NewRange(hm(9, 0), hm(13, 0)), true,
+			Range{}, false,
+		},
+		// Adjacent.
+		1: {
+			NewRange(hm(9, 0), hm(12, 0)), NewRange(hm(12, 0), hm(13, 0)),
+			false, Range{},
+			NewRange(hm(9, 0), hm(13, 0)), true,
+			Range{}, false,
+		},
+		// Disjoint, in reverse order.
+		2: {
+			NewRange(hm(14, 0), hm(15, 0)), NewRange(hm(9, 0), hm(12, 0)),
+			false, Range{},
+			Range{}, false,
+			NewRange(hm(12, 0), hm(14, 0)), true,
+		},
+		// Nested.
+		3: {
+			NewRange(hm(9, 0), hm(17, 0)), NewRange(hm(10, 0), hm(11, 0)),
+			true, NewRange(hm(10, 0), hm(11, 0)),
+			NewRange(hm(9, 0), hm(17, 0)), true,
+			Range{}, false,
+		},
+		// Empty.
+		4: {
+			NewRange(hm(9, 0), hm(17, 0)), NewRange(hm(10, 0), hm(10, 0)),
+			false, Range{},
+			NewRange(hm(9, 0), hm(17, 0)), true,
+			Range{}, false,
+		},
+	}
+
+	eq := func(a, b Range) bool {
+		return a.Start.Equal(b.Start) && a.End.Equal(b.End)
+	}
+	for i, tt := range tests {
+		if got := tt.r.Overlaps(tt.s); got != tt.overlaps {
+			t.Errorf("#%d:: Overlaps() = %v, want %v", i, got, tt.overlaps)
+		}
+		if got, ok := tt.r.Intersect(tt.s); ok != tt.overlaps || !eq(got, tt.intersect) {
+			t.Errorf("#%d:: Intersect() = %v, %v, want %v, %v", i, got, ok, tt.intersect, tt.overlaps)
+		}
+		if got, ok := tt.r.Union(tt.s); ok != tt.unionOK || !eq(got, tt.union) {
+			t.Errorf("#%d:: Union() = %v, %v, want %v, %v", i, got, ok, tt.union, tt.unionOK)
+		}
+		if got, ok := tt.r.Gap(tt.s); ok != tt.gapOK || !eq(got, tt.gap) {
+			t.Errorf("#%d:: Gap() = %v, %v, want %v, %v", i, got, ok, tt.gap, tt.gapOK)
+		}
+	}
+}
+
+func TestRangeDuration(t *testing.T) {
+	if got := NewRange(hm(9, 0), hm(17, 30)).Duration(); got != 8*time.Hour+30*time.Minute {
+		t.Errorf("Duration() = %v, want 8h30m", got)
+	}
+	if got := NewRange(hm(17, 0), hm(9, 0)).Duration(); got != 0 {
+		t.Errorf("Duration() of reversed range = %v, want 0", got)
+	}
+	if !NewRange(hm(9, 0), hm(17, 0)).ContainsRange(NewRange(hm(9, 0), hm(17, 0))) {
+		t.Error("ContainsRange() of itself = false, want true")
+	}
+}
+
+func TestRangeSplit(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [...]struct {
+		r    Range
+		u    Unit
+		want []time.Duration
+	}{
+		0: {NewRange(Date(2023, January, 15, 12, 0, 0, 0, UTC), Date(2023, April, 2, 0, 0, 0, 0, UTC)), UnitMonth,
+			[]time.Duration{(16*24 + 12) * time.Hour, 28 * 24 * time.Hour, 31 * 24 * time.Hour, 24 * time.Hour}},
+		// The day DST starts is 23 hours long.
+		1: {NewRange(Date(2023, March, 11, 12, 0, 0, 0, la), Date(2023, March, 13, 6, 0, 0, 0, la)), UnitDay,
+			[]time.Duration{12 * time.Hour, 23 * time.Hour, 6 * time.Hour}},
+		// October 16, 2023 is a Monday.
+		2: {NewRange(Date(2023, October, 12, 0, 0, 0, 0, UTC), Date(2023, October, 24, 0, 0, 0, 0, UTC)), UnitWeek,
+			[]time.Duration{4 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour}},
+		3: {NewRange(hm(9, 30), hm(11, 0)), UnitHour, []time.Duration{30 * time.Minute, time.Hour}},
+		4: {NewRange(hm(9, 0), hm(9, 0)), UnitHour, nil},
+	}
+
+	for i, tt := range tests {
+		parts := tt.r.Split(tt.u)
+		if len(parts) != len(tt.want) {
+			t.Errorf("#%d:: Split(%v) = %v, want %d parts", i, tt.u, parts, len(tt.want))
+			continue
+		}
+		for j, p := range parts {
+			if p.Duration() != tt.want[j] {
+				t.Errorf("#%d:: part %d = %v, want a duration of %v", i, j, p, tt.want[j])
+			}
+			if j > 0 && !p.Start.Equal(parts[j-1].End) {
+				t.Errorf("#%d:: part %d starts at %v, want %v", i, j, p.Start, parts[j-1].End)
+			}
+		}
+		if len(parts) > 0 && (!parts[0].Start.Equal(tt.r.Start) || !parts[len(parts)-1].End.Equal(tt.r.End)) {
+			t.Errorf("#%d:: Split(%v) = %v, want to cover %v", i, tt.u, parts, tt.r)
+		}
+	}
+}
+
+func TestRangeJSON(t *testing.T) {
+	r := NewRange(
+		Date(2023, October, 16, 0, 0, 0, 0, UTC, "2006-01-02"),
+		Date(2023, October, 17, 9, 30, 0, 0, UTC),
+	)
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"start":"2023-10-16","end":"2023-10-17T09:30:00Z"}`; string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+
+	got := NewRange(New("2006-01-02"), New())
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Start.Equal(r.Start) || !got.End.Equal(r.End) {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", b, got, r)
+	}
+	if err := json.Unmarshal([]byte(`{"start":"16/10/2023"}`), &got); err == nil {
+		t.Error("json.Unmarshal() of a start in the wrong layout succeeded, want error")
+	}
+}
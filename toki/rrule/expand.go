@@ -0,0 +1,365 @@
+package rrule
+
+import (
+	"sort"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+// Between returns the occurrences of r starting at dtstart, from start,
+// included, to end, excluded, in order. The occurrences have the location
+// and layout of dtstart, and are computed on its wall clock: a local time
+// skipped by a transition is read with the offset before it, and one that
+// is repeated is its first occurrence. Unlike Set.Between, dtstart is
+// not an occurrence unless it matches r.
+func (r *Rule) Between(dtstart, start, end toki.Toki) []toki.Toki {
+	var ts []toki.Toki
+	r.expand(dtstart, start, end, func(t toki.Toki) bool {
+		if !t.Before(end) {
+			return false
+		}
+		if !t.Before(start) {
+			ts = append(ts, t)
+		}
+		return true
+	})
+	return ts
+}
+
+// An expansion holds the parts of a rule with the defaults taken from
+// DTSTART.
+type expansion struct {
+	r          *Rule
+	byMonth    []int
+	byMonthDay []int
+	byDay      []WeekdayNum
+	byHour     []int
+	byMinute   []int
+	bySecond   []int
+}
+
+func newExpansion(r *Rule, ds time.Time) *expansion {
+	e := &expansion{
+		r:          r,
+		byMonth:    r.ByMonth,
+		byMonthDay: r.ByMonthDay,
+		byDay:      r.ByDay,
+		byHour:     sortedInts(r.ByHour),
+		byMinute:   sortedInts(r.ByMinute),
+		bySecond:   sortedInts(r.BySecond),
+	}
+	// A rule without day parts repeats the day of DTSTART in its period.
+	if len(r.ByWeekNo) == 0 && len(r.ByYearDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		switch r.Freq {
+		case Yearly:
+			if len(e.byMonth) == 0 {
+				e.byMonth = []int{int(ds.Month())}
+			}
+			e.byMonthDay = []int{ds.Day()}
+		case Monthly:
+			e.byMonthDay = []int{ds.Day()}
+		case Weekly:
+			e.byDay = []WeekdayNum{{Weekday: ds.Weekday()}}
+		}
+	}
+	// Likewise for the time of DTSTART, in periods longer than its parts.
+	if len(e.byHour) == 0 && r.Freq > Hourly {
+		e.byHour = []int{ds.Hour()}
+	}
+	if len(e.byMinute) == 0 && r.Freq > Minutely {
+		e.byMinute = []int{ds.Minute()}
+	}
+	if len(e.bySecond) == 0 && r.Freq > Secondly {
+		e.bySecond = []int{ds.Second()}
+	}
+	return e
+}
+
+func sortedInts(vs []int) []int {
+	vs = append([]int(nil), vs...)
+	sort.Ints(vs)
+	return vs
+}
+
+// wall returns the wall clock of t, to the second, as a time in UTC.
+func wall(t toki.Toki) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// expand calls yield with the occurrences of r in order, until it returns
+// false or the occurrences run out. Periods ending before start are
+// skipped unless the rule has a COUNT.
+func (r *Rule) expand(dtstart, start, end toki.Toki, yield func(toki.Toki) bool) {
+	loc := dtstart.Location()
+	layout := dtstart.GetLayout()
+	ds := wall(dtstart)
+	e := newExpansion(r, ds)
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	// Candidates are in wall clock time, and a day of margin covers any
+	// transition.
+	limit := wall(end.In(loc)).AddDate(0, 0, 1)
+	var until time.Time
+	if !r.Until.IsZero() {
+		until = r.Until.Time
+		if r.untilFloating {
+			until = inLocation(r.Until, loc).Time
+		}
+		if u := wall(toki.Toki{Time: until.In(loc)}).AddDate(0, 0, 1); u.Before(limit) {
+			limit = u
+		}
+	}
+
+	first := 0
+	if r.Count == 0 {
+		first = e.periodsBefore(ds, wall(start.In(loc)).AddDate(0, 0, -1)) / interval
+	}
+	count := 0
+	for n := first; ; n++ {
+		p := e.period(ds, n*interval)
+		if p.After(limit) {
+			return
+		}
+		for _, c := range e.candidates(p) {
+			if c.Before(ds) {
+				continue
+			}
+			t := inLocation(toki.Toki{Time: c}, loc, layout)
+			if !until.IsZero() && t.Time.After(until) {
+				return
+			}
+			if !yield(t) {
+				return
+			}
+			if count++; r.Count > 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// period returns the start of the kth period from the one of ds.
+func (e *expansion) period(ds time.Time, k int) time.Time {
+	y, m, d := ds.Date()
+	switch e.r.Freq {
+	case Yearly:
+		return time.Date(y+k, time.January, 1, 0, 0, 0, 0, time.UTC)
+	case Monthly:
+		return time.Date(y, m+time.Month(k), 1, 0, 0, 0, 0, time.UTC)
+	case Weekly:
+		back := (int(ds.Weekday()) - int(e.r.WeekStart) + 7) % 7
+		return time.Date(y, m, d-back+7*k, 0, 0, 0, 0, time.UTC)
+	case Daily:
+		return time.Date(y, m, d+k, 0, 0, 0, 0, time.UTC)
+	case Hourly:
+		return ds.Truncate(time.Hour).Add(time.Duration(k) * time.Hour)
+	case Minutely:
+		return ds.Truncate(time.Minute).Add(time.Duration(k) * time.Minute)
+	}
+	return ds.Add(time.Duration(k) * time.Second)
+}
+
+// periodsBefore returns the number of whole periods from the one of ds to
+// t, or 0 if t is before ds.
+func (e *expansion) periodsBefore(ds, t time.Time) int {
+	if !t.After(ds) {
+		return 0
+	}
+	days := func() int {
+		return int(t.Sub(ds) / (24 * time.Hour))
+	}
+	switch e.r.Freq {
+	case Yearly:
+		return t.Year() - ds.Year()
+	case Monthly:
+		return (t.Year()-ds.Year())*12 + int(t.Month()-ds.Month())
+	case Weekly:
+		return days() / 7
+	case Daily:
+		return days()
+	case Hourly:
+		return int(t.Sub(ds) / time.Hour)
+	case Minutely:
+		return int(t.Sub(ds) / time.Minute)
+	}
+	return int(t.Sub(ds) / time.Second)
+}
+
+// candidates returns the occurrences of the period starting at p, in
+// order, before the checks against DTSTART, UNTIL and COUNT.
+func (e *expansion) candidates(p time.Time) []time.Time {
+	var days []time.Time
+	switch e.r.Freq {
+	case Yearly:
+		for d := p; d.Year() == p.Year(); d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+	case Monthly:
+		for d := p; d.Month() == p.Month(); d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+	case Weekly:
+		for i := 0; i < 7; i++ {
+			days = append(days, p.AddDate(0, 0, i))
+		}
+	default:
+		y, m, d := p.Date()
+		days = append(days, time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+
+	hours, minutes, seconds := e.byHour, e.byMinute, e.bySecond
+	switch e.r.Freq {
+	case Secondly:
+		seconds = filterInts(seconds, p.Second())
+		fallthrough
+	case Minutely:
+		minutes = filterInts(minutes, p.Minute())
+		fallthrough
+	case Hourly:
+		hours = filterInts(hours, p.Hour())
+	}
+
+	var cs []time.Time
+	for _, d := range days {
+		if !e.dayMatches(d) {
+			continue
+		}
+		for _, h := range hours {
+			for _, m := range minutes {
+				for _, s := range seconds {
+					cs = append(cs, d.Add(time.Duration(h)*time.Hour+time.Duration(m)*time.Minute+time.Duration(s)*time.Second))
+				}
+			}
+		}
+	}
+	if len(e.r.BySetPos) > 0 {
+		cs = e.setPos(cs)
+	}
+	return cs
+}
+
+// filterInts returns the list of v if vs is empty or contains v, and an
+// empty list otherwise.
+func filterInts(vs []int, v int) []int {
+	if len(vs) == 0 || containsInt(vs, v) {
+		return []int{v}
+	}
+	return nil
+}
+
+func containsInt(vs []int, v int) bool {
+	for _, x := range vs {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// setPos selects the candidates at the positions of BYSETPOS.
+func (e *expansion) setPos(cs []time.Time) []time.Time {
+	var idx []int
+	for _, pos := range e.r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(cs) + pos
+		}
+		if i >= 0 && i < len(cs) && !containsInt(idx, i) {
+			idx = append(idx, i)
+		}
+	}
+	sort.Ints(idx)
+	out := make([]time.Time, len(idx))
+	for j, i := range idx {
+		out[j] = cs[i]
+	}
+	return out
+}
+
+// dayMatches reports whether d passes the day parts of the rule.
+func (e *expansion) dayMatches(d time.Time) bool {
+	r := e.r
+	if len(e.byMonth) > 0 && !containsInt(e.byMonth, int(d.Month())) {
+		return false
+	}
+	if len(r.ByWeekNo) > 0 && !weekNoMatches(d, r.ByWeekNo, r.WeekStart) {
+		return false
+	}
+	yearLen := time.Date(d.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	if len(r.ByYearDay) > 0 && !ordinalMatches(r.ByYearDay, d.YearDay(), yearLen) {
+		return false
+	}
+	monthLen := toki.DaysIn(d.Month(), d.Year())
+	if len(e.byMonthDay) > 0 && !ordinalMatches(e.byMonthDay, d.Day(), monthLen) {
+		return false
+	}
+	if len(e.byDay) == 0 {
+		return true
+	}
+
+	// The occurrence of the weekday counts in the month for MONTHLY
+	// rules, and in the year for YEARLY ones unless BYMONTH narrows it.
+	nth, length := 0, 0
+	switch {
+	case r.Freq == Monthly || r.Freq == Yearly && len(r.ByMonth) > 0:
+		nth, length = d.Day(), monthLen
+	case r.Freq == Yearly && len(r.ByWeekNo) == 0:
+		nth, length = d.YearDay(), yearLen
+	}
+	for _, w := range e.byDay {
+		if w.Weekday != d.Weekday() {
+			continue
+		}
+		if w.N == 0 || length == 0 {
+			return true
+		}
+		if w.N > 0 && (nth-1)/7+1 == w.N || w.N < 0 && -((length-nth)/7+1) == w.N {
+			return true
+		}
+	}
+	return false
+}
+
+// ordinalMatches reports whether the nth of length days is in vs, where
+// negative values count from the end.
+func ordinalMatches(vs []int, n, length int) bool {
+	for _, v := range vs {
+		if v == n || v < 0 && length+1+v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// weekNoMatches reports whether d is in one of the weeks of weeks. Week 1
+// is the first week with at least four days of the year, and weeks start
+// on wkst.
+func weekNoMatches(d time.Time, weeks []int, wkst time.Weekday) bool {
+	y := d.Year()
+	start := week1Start(y, wkst)
+	if d.Before(start) {
+		y--
+		start = week1Start(y, wkst)
+	} else if next := week1Start(y+1, wkst); !d.Before(next) {
+		y++
+		start = next
+	}
+	n := int(d.Sub(start)/(24*time.Hour))/7 + 1
+	count := int(week1Start(y+1, wkst).Sub(start)/(24*time.Hour)) / 7
+	for _, w := range weeks {
+		if w == n || w < 0 && count+1+w == n {
+			return true
+		}
+	}
+	return false
+}
+
+// week1Start returns the first day of week 1 of year.
+func week1Start(year int, wkst time.Weekday) time.Time {
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	return jan4.AddDate(0, 0, -((int(jan4.Weekday()) - int(wkst) + 7) % 7))
+}
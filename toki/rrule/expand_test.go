@@ -0,0 +1,252 @@
+package rrule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+// TestRFC5545Examples checks the examples of RRULE in section 3.8.5.3 of
+// RFC 5545, with DTSTART in America/New_York. want holds the first
+// occurrences, and total, if not zero, the number of all of them.
+func TestRFC5545Examples(t *testing.T) {
+	ny := newYork(t)
+	tests := [...]struct {
+		dtstart string
+		rule    string
+		want    string
+		total   int
+	}{
+		// Daily for 10 occurrences.
+		0: {"19970902T090000", "FREQ=DAILY;COUNT=10",
+			"19970902T090000 19970903T090000 19970904T090000 19970905T090000 19970906T090000 " +
+				"19970907T090000 19970908T090000 19970909T090000 19970910T090000 19970911T090000", 10},
+		// Daily until December 24, 1997, across the end of DST.
+		1: {"19970902T090000", "FREQ=DAILY;UNTIL=19971224T000000Z",
+			"19970902T090000 19970903T090000", 113},
+		// Every other day, forever.
+		2: {"19970902T090000", "FREQ=DAILY;INTERVAL=2",
+			"19970902T090000 19970904T090000 19970906T090000 19970908T090000", 0},
+		// Every 10 days, 5 occurrences.
+		3: {"19970902T090000", "FREQ=DAILY;INTERVAL=10;COUNT=5",
+			"19970902T090000 19970912T090000 19970922T090000 19971002T090000 19971012T090000", 5},
+		// Every day in January, for 3 years.
+		4: {"19980101T090000", "FREQ=YEARLY;UNTIL=20000131T140000Z;BYMONTH=1;BYDAY=SU,MO,TU,WE,TH,FR,SA",
+			"19980101T090000 19980102T090000", 93},
+		5: {"19980101T090000", "FREQ=DAILY;UNTIL=20000131T140000Z;BYMONTH=1",
+			"19980101T090000 19980102T090000", 93},
+		// Weekly for 10 occurrences.
+		6: {"19970902T090000", "FREQ=WEEKLY;COUNT=10",
+			"19970902T090000 19970909T090000 19970916T090000 19970923T090000 19970930T090000 " +
+				"19971007T090000 19971014T090000 19971021T090000 19971028T090000 19971104T090000", 10},
+		// Weekly until December 24, 1997.
+		7: {"19970902T090000", "FREQ=WEEKLY;UNTIL=19971224T000000Z", "19970902T090000", 17},
+		// Every other week, forever.
+		8: {"19970902T090000", "FREQ=WEEKLY;INTERVAL=2;WKST=SU",
+			"19970902T090000 19970916T090000 19970930T090000 19971014T090000", 0},
+		// Weekly on Tuesday and Thursday for five weeks.
+		9: {"19970902T090000", "FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH",
+			"19970902T090000 19970904T090000 19970909T090000 19970911T090000 19970916T090000 " +
+				"19970918T090000 19970923T090000 19970925T090000 19970930T090000 19971002T090000", 10},
+		10: {"19970902T090000", "FREQ=WEEKLY;COUNT=10;WKST=SU;BYDAY=TU,TH",
+			"19970902T090000 19970904T090000 19970909T090000 19970911T090000 19970916T090000 " +
+				"19970918T090000 19970923T090000 19970925T090000 19970930T090000 19971002T090000", 10},
+		// Every other week on Monday, Wednesday and Friday until December 24, 1997.
+		11: {"19970901T090000", "FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR",
+			"19970901T090000 19970903T090000 19970905T090000 19970915T090000 19970917T090000", 25},
+		// Every other week on Tuesday and Thursday, for 8 occurrences.
+		12: {"19970902T090000", "FREQ=WEEKLY;INTERVAL=2;COUNT=8;WKST=SU;BYDAY=TU,TH",
+			"19970902T090000 19970904T090000 19970916T090000 19970918T090000 " +
+				"19970930T090000 19971002T090000 19971014T090000 19971016T090000", 8},
+		// Monthly on the first Friday for 10 occurrences.
+		13: {"19970905T090000", "FREQ=MONTHLY;COUNT=10;BYDAY=1FR",
+			"19970905T090000 19971003T090000 19971107T090000 19971205T090000 19980102T090000 " +
+				"19980206T090000 19980306T090000 19980403T090000 19980501T090000 19980605T090000", 10},
+		// Every other month on the first and last Sunday for 10 occurrences.
+		14: {"19970907T090000", "FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYDAY=1SU,-1SU",
+			"19970907T090000 19970928T090000 19971102T090000 19971130T090000 19980104T090000 " +
+				"19980125T090000 19980301T090000 19980329T090000 19980503T090000 19980531T090000", 10},
+		// Monthly on the second-to-last Monday for 6 months.
+		15: {"19970922T090000", "FREQ=MONTHLY;COUNT=6;BYDAY=-2MO",
+			"19970922T090000 19971020T090000 19971117T090000 19971222T090000 19980119T090000 19980216T090000", 6},
+		// Monthly on the third-to-last day, forever.
+		16: {"19970928T090000", "FREQ=MONTHLY;BYMONTHDAY=-3",
+			"19970928T090000 19971029T090000 19971128T090000 19971229T090000 19980129T090000 19980226T090000", 0},
+		// Monthly on the 2nd and 15th for 10 occurrences.
+		17: {"19970902T090000", "FREQ=MONTHLY;COUNT=10;BYMONTHDAY=2,15",
+			"19970902T090000 19970915T090000 19971002T090000 19971015T090000 19971102T090000 " +
+				"19971115T090000 19971202T090000 19971215T090000 19980102T090000 19980115T090000", 10},
+		// Monthly on the first and last day for 10 occurrences.
+		18: {"19970930T090000", "FREQ=MONTHLY;COUNT=10;BYMONTHDAY=1,-1",
+			"19970930T090000 19971001T090000 19971031T090000 19971101T090000 19971130T090000 " +
+				"19971201T090000 19971231T090000 19980101T090000 19980131T090000 19980201T090000", 10},
+		// Every 18 months on the 10th to 15th for 10 occurrences.
+		19: {"19970910T090000", "FREQ=MONTHLY;INTERVAL=18;COUNT=10;BYMONTHDAY=10,11,12,13,14,15",
+			"19970910T090000 19970911T090000 19970912T090000 19970913T090000 19970914T090000 " +
+				"19970915T090000 19990310T090000 19990311T090000 19990312T090000 19990313T090000", 10},
+		// Every Tuesday, every other month.
+		20: {"19970902T090000", "FREQ=MONTHLY;INTERVAL=2;BYDAY=TU",
+			"19970902T090000 19970909T090000 19970916T090000 19970923T090000 19970930T090000 " +
+				"19971104T090000 19971111T090000 19971118T090000 19971125T090000 " +
+				"19980106T090000 19980113T090000 19980120T090000 19980127T090000 19980303T090000", 0},
+		// Yearly in June and July for 10 occurrences.
+		21: {"19970610T090000", "FREQ=YEARLY;COUNT=10;BYMONTH=6,7",
+			"19970610T090000 19970710T090000 19980610T090000 19980710T090000 19990610T090000 " +
+				"19990710T090000 20000610T090000 20000710T090000 20010610T090000 20010710T090000", 10},
+		// Every other year on January, February and March for 10 occurrences.
+		22: {"19970310T090000", "FREQ=YEARLY;INTERVAL=2;COUNT=10;BYMONTH=1,2,3",
+			"19970310T090000 19990110T090000 19990210T090000 19990310T090000 20010110T090000 " +
+				"20010210T090000 20010310T090000 20030110T090000 20030210T090000 20030310T090000", 10},
+		// Every third year on the 1st, 100th and 200th day for 10 occurrences.
+		23: {"19970101T090000", "FREQ=YEARLY;INTERVAL=3;COUNT=10;BYYEARDAY=1,100,200",
+			"19970101T090000 19970410T090000 19970719T090000 20000101T090000 20000409T090000 " +
+				"20000718T090000 20030101T090000 20030410T090000 20030719T090000 20060101T090000", 10},
+		// Every 20th Monday of the year, forever.
+		24: {"19970519T090000", "FREQ=YEARLY;BYDAY=20MO",
+			"19970519T090000 19980518T090000 19990517T090000", 0},
+		// Monday of week number 20, forever.
+		25: {"19970512T090000", "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO",
+			"19970512T090000 19980511T090000 19990517T090000", 0},
+		// Every Thursday in March, forever.
+		26: {"19970313T090000", "FREQ=YEARLY;BYMONTH=3;BYDAY=TH",
+			"19970313T090000 19970320T090000 19970327T090000 19980305T090000 19980312T090000 " +
+				"19980319T090000 19980326T090000 19990304T090000 19990311T090000 19990318T090000 19990325T090000", 0},
+		// Every Thursday, but only during June, July and August, forever.
+		27: {"19970605T090000", "FREQ=YEARLY;BYDAY=TH;BYMONTH=6,7,8",
+			"19970605T090000 19970612T090000 19970619T090000 19970626T090000 19970703T090000", 0},
+		// Every Friday the 13th, forever; DTSTART is excluded by the set.
+		28: {"19970902T090000", "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			"19980213T090000 19980313T090000 19981113T090000 19990813T090000 20001013T090000", 0},
+		// The first Saturday that follows the first Sunday of the month, forever.
+		29: {"19970913T090000", "FREQ=MONTHLY;BYDAY=SA;BYMONTHDAY=7,8,9,10,11,12,13",
+			"19970913T090000 19971011T090000 19971108T090000 19971213T090000 19980110T090000 " +
+				"19980207T090000 19980307T090000 19980411T090000 19980509T090000 19980613T090000", 0},
+		// Every 4 years, the first Tuesday after a Monday in November, forever.
+		30: {"19961105T090000", "FREQ=YEARLY;INTERVAL=4;BYMONTH=11;BYDAY=TU;BYMONTHDAY=2,3,4,5,6,7,8",
+			"19961105T090000 20001107T090000 20041102T090000", 0},
+		// The third instance of Tuesday, Wednesday or Thursday, for the next 3 months.
+		31: {"19970904T090000", "FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3",
+			"19970904T090000 19971007T090000 19971106T090000", 3},
+		// The second-to-last weekday of the month.
+		32: {"19970929T090000", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2",
+			"19970929T090000 19971030T090000 19971127T090000 19971230T090000 19980129T090000 " +
+				"19980226T090000 19980330T090000", 0},
+		// Every 3 hours from 09:00 to 17:00 on a specific day. The RFC
+		// gives UNTIL=19970902T170000Z, which is 13:00 in New York, with
+		// the results of a local UNTIL.
+		33: {"19970902T090000", "FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T170000",
+			"19970902T090000 19970902T120000 19970902T150000", 3},
+		// Every 15 minutes for 6 occurrences.
+		34: {"19970902T090000", "FREQ=MINUTELY;INTERVAL=15;COUNT=6",
+			"19970902T090000 19970902T091500 19970902T093000 19970902T094500 19970902T100000 19970902T101500", 6},
+		// Every hour and a half for 4 occurrences.
+		35: {"19970902T090000", "FREQ=MINUTELY;INTERVAL=90;COUNT=4",
+			"19970902T090000 19970902T103000 19970902T120000 19970902T133000", 4},
+		// Every 20 minutes from 9:00 to 16:40 every day.
+		36: {"19970902T090000", "FREQ=DAILY;BYHOUR=9,10,11,12,13,14,15,16;BYMINUTE=0,20,40",
+			"19970902T090000 19970902T092000 19970902T094000 19970902T100000 19970902T102000", 0},
+		37: {"19970902T090000", "FREQ=MINUTELY;INTERVAL=20;BYHOUR=9,10,11,12,13,14,15,16",
+			"19970902T090000 19970902T092000 19970902T094000 19970902T100000 19970902T102000", 0},
+		// The effect of WKST.
+		38: {"19970805T090000", "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			"19970805T090000 19970810T090000 19970819T090000 19970824T090000", 4},
+		39: {"19970805T090000", "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			"19970805T090000 19970817T090000 19970819T090000 19970831T090000", 4},
+		// An invalid date, February 30, is ignored.
+		40: {"20070115T090000", "FREQ=MONTHLY;BYMONTHDAY=15,30;COUNT=5",
+			"20070115T090000 20070130T090000 20070215T090000 20070315T090000 20070330T090000", 5},
+	}
+
+	for i, tt := range tests {
+		r, err := ParseRule(tt.rule)
+		if err != nil {
+			t.Errorf("#%d:: ParseRule(%q) error = %v", i, tt.rule, err)
+			continue
+		}
+		dtstart := parseLocal(t, tt.dtstart, ny)
+		got := r.Between(dtstart, dtstart, dtstart.AddDate(10, 0, 0))
+		want := strings.Fields(tt.want)
+		if tt.total > 0 && len(got) != tt.total {
+			t.Errorf("#%d:: %q has %d occurrences, want %d", i, tt.rule, len(got), tt.total)
+		}
+		if len(got) < len(want) {
+			t.Errorf("#%d:: %q = %v, want %v", i, tt.rule, formatLocal(got, ny), want)
+			continue
+		}
+		if g := formatLocal(got[:len(want)], ny); strings.Join(g, " ") != tt.want {
+			t.Errorf("#%d:: %q = %v, want %v", i, tt.rule, g, want)
+		}
+	}
+}
+
+func parseLocal(t *testing.T, s string, loc *time.Location) toki.Toki {
+	t.Helper()
+	v, err := time.ParseInLocation(toki.LayoutICalendarFloating, s, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return toki.Toki{Time: v}
+}
+
+func formatLocal(ts []toki.Toki, loc *time.Location) []string {
+	s := make([]string, len(ts))
+	for i, t := range ts {
+		s[i] = t.In(loc).Format(toki.LayoutICalendarFloating)
+	}
+	return s
+}
+
+func TestBetweenWindow(t *testing.T) {
+	ny := newYork(t)
+	dtstart := parseLocal(t, "19970902T090000", ny)
+	r := MustParseRule("FREQ=DAILY")
+
+	// The window starts after DTSTART, and ends before the occurrence at
+	// its end.
+	got := r.Between(dtstart, parseLocal(t, "20230310T000000", ny), parseLocal(t, "20230314T090000", ny))
+	want := "20230310T090000 20230311T090000 20230312T090000 20230313T090000"
+	if g := strings.Join(formatLocal(got, ny), " "); g != want {
+		t.Errorf("Between() = %s, want %s", g, want)
+	}
+	// The time stays at 09:00 across the start of DST on March 12.
+	if d := got[2].Sub(got[1]); d != 23*time.Hour {
+		t.Errorf("day of the DST transition lasted %v, want 23h", d)
+	}
+
+	// COUNT is counted from DTSTART.
+	r = MustParseRule("FREQ=DAILY;COUNT=10")
+	got = r.Between(dtstart, parseLocal(t, "19970910T000000", ny), parseLocal(t, "19971231T000000", ny))
+	if g := strings.Join(formatLocal(got, ny), " "); g != "19970910T090000 19970911T090000" {
+		t.Errorf("Between() with COUNT = %s", g)
+	}
+}
+
+func TestDSTGap(t *testing.T) {
+	ny := newYork(t)
+	// 02:30 does not exist on March 9, 2008, and is read with the offset
+	// before the transition, as 03:30 EDT.
+	dtstart := parseLocal(t, "20080308T023000", ny)
+	got := MustParseRule("FREQ=DAILY;COUNT=3").Between(dtstart, dtstart, dtstart.AddDate(0, 0, 5))
+	want := "20080308T023000 20080309T033000 20080310T023000"
+	if g := strings.Join(formatLocal(got, ny), " "); g != want {
+		t.Errorf("Between() = %s, want %s", g, want)
+	}
+
+	// 01:30 occurs twice on November 2, 2008, and is the first one.
+	dtstart = parseLocal(t, "20081101T013000", ny)
+	got = MustParseRule("FREQ=DAILY;COUNT=2").Between(dtstart, dtstart, dtstart.AddDate(0, 0, 5))
+	if _, offset := got[1].Zone(); offset != -4*60*60 {
+		t.Errorf("second occurrence %v, want EDT", got[1])
+	}
+}
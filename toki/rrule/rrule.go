@@ -0,0 +1,287 @@
+// Package rrule expands recurrence rules of iCalendar (RFC 5545) into
+// toki.Toki values.
+package rrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+// A Frequency is the FREQ of a rule, the period over which it repeats.
+type Frequency int
+
+const (
+	Secondly Frequency = iota
+	Minutely
+	Hourly
+	Daily
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = [...]string{
+	Secondly: "SECONDLY",
+	Minutely: "MINUTELY",
+	Hourly:   "HOURLY",
+	Daily:    "DAILY",
+	Weekly:   "WEEKLY",
+	Monthly:  "MONTHLY",
+	Yearly:   "YEARLY",
+}
+
+func (f Frequency) String() string {
+	if f >= 0 && int(f) < len(frequencyNames) {
+		return frequencyNames[f]
+	}
+	return fmt.Sprintf("Frequency(%d)", int(f))
+}
+
+// A WeekdayNum is an element of BYDAY: a day of the week, and with
+// MONTHLY or YEARLY rules, its Nth occurrence in the month or year,
+// counted from the end if negative. N is 0 for every occurrence.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayCodes[w.Weekday]
+	}
+	return strconv.Itoa(w.N) + weekdayCodes[w.Weekday]
+}
+
+// A Rule is a recurrence rule, the value of an RRULE property. It is
+// expanded from the DTSTART of an event by Set or Rule.Between.
+type Rule struct {
+	Freq Frequency
+
+	// Until is the last instant at which the rule may occur, if not zero.
+	Until toki.Toki
+
+	// Count is the number of occurrences, if not zero.
+	Count int
+
+	// Interval is the number of periods between occurrences, 1 if zero.
+	Interval int
+
+	BySecond   []int
+	ByMinute   []int
+	ByHour     []int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByMonth    []int
+	BySetPos   []int
+
+	// WeekStart is the first day of the week, for WEEKLY rules and
+	// BYWEEKNO. ParseRule sets it to Monday, the default of RFC 5545.
+	WeekStart time.Weekday
+
+	// untilFloating reports whether Until was given as a local time or a
+	// date, read in the location of DTSTART.
+	untilFloating bool
+}
+
+// ParseRule parses the value of an RRULE property, such as
+// "FREQ=MONTHLY;BYDAY=MO,TU;BYSETPOS=-1", with or without the "RRULE:"
+// name. An UNTIL without "Z" is read in the location of DTSTART when the
+// rule is expanded; a DATE UNTIL includes the whole day.
+func ParseRule(s string) (*Rule, error) {
+	value := strings.TrimSpace(s)
+	if len(value) >= 6 && strings.EqualFold(value[:6], "RRULE:") {
+		value = value[6:]
+	}
+	r := &Rule{Freq: -1, WeekStart: time.Monday}
+	bad := func(part string, err error) (*Rule, error) {
+		return nil, fmt.Errorf("rrule: invalid %s in %q: %w", part, s, err)
+	}
+	hasUntil := false
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		name, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("rrule: invalid part %q in %q", part, s)
+		}
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Freq, err = parseFrequency(v)
+		case "UNTIL":
+			hasUntil = true
+			var t dateTime
+			if t, err = parseDateTime(v, nil); err == nil {
+				r.Until, r.untilFloating = t.Toki, t.floating
+				if t.date {
+					r.Until = r.Until.Add(24*time.Hour - time.Nanosecond)
+				}
+			}
+		case "COUNT":
+			if r.Count, err = strconv.Atoi(v); err == nil && r.Count <= 0 {
+				err = fmt.Errorf("%d is not positive", r.Count)
+			}
+		case "INTERVAL":
+			if r.Interval, err = strconv.Atoi(v); err == nil && r.Interval <= 0 {
+				err = fmt.Errorf("%d is not positive", r.Interval)
+			}
+		case "BYSECOND":
+			r.BySecond, err = parseInts(v, 0, 60, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseInts(v, 0, 59, false)
+		case "BYHOUR":
+			r.ByHour, err = parseInts(v, 0, 23, false)
+		case "BYDAY":
+			r.ByDay, err = parseWeekdayNums(v)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(v, 1, 31, true)
+		case "BYYEARDAY":
+			r.ByYearDay, err = parseInts(v, 1, 366, true)
+		case "BYWEEKNO":
+			r.ByWeekNo, err = parseInts(v, 1, 53, true)
+		case "BYMONTH":
+			r.ByMonth, err = parseInts(v, 1, 12, false)
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(v, 1, 366, true)
+		case "WKST":
+			r.WeekStart, err = parseWeekday(v)
+		default:
+			err = fmt.Errorf("unknown part")
+		}
+		if err != nil {
+			return bad(strings.ToUpper(name), err)
+		}
+	}
+	switch {
+	case r.Freq < 0:
+		return nil, fmt.Errorf("rrule: missing FREQ in %q", s)
+	case hasUntil && r.Count > 0:
+		return nil, fmt.Errorf("rrule: both UNTIL and COUNT in %q", s)
+	}
+	return r, nil
+}
+
+// MustParseRule is like ParseRule but panics if s cannot be parsed.
+func MustParseRule(s string) *Rule {
+	r, err := ParseRule(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func parseFrequency(s string) (Frequency, error) {
+	for f, name := range frequencyNames {
+		if strings.EqualFold(s, name) {
+			return Frequency(f), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown frequency %q", s)
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for wd, code := range weekdayCodes {
+		if strings.EqualFold(s, code) {
+			return time.Weekday(wd), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
+
+// parseInts parses a list of integers between min and max, or between
+// -max and -min if negative is true.
+func parseInts(s string, min, max int, negative bool) ([]int, error) {
+	var vs []int
+	for _, item := range strings.Split(s, ",") {
+		v, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", item)
+		}
+		abs := v
+		if negative && v < 0 {
+			abs = -v
+		}
+		if abs < min || abs > max {
+			return nil, fmt.Errorf("value %d out of range", v)
+		}
+		vs = append(vs, v)
+	}
+	return vs, nil
+}
+
+func parseWeekdayNums(s string) ([]WeekdayNum, error) {
+	var ws []WeekdayNum
+	for _, item := range strings.Split(s, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+		wd, err := parseWeekday(item[len(item)-2:])
+		if err != nil {
+			return nil, err
+		}
+		w := WeekdayNum{Weekday: wd}
+		if n := item[:len(item)-2]; n != "" {
+			if w.N, err = strconv.Atoi(n); err != nil || w.N == 0 || w.N < -53 || w.N > 53 {
+				return nil, fmt.Errorf("invalid weekday %q", item)
+			}
+		}
+		ws = append(ws, w)
+	}
+	return ws, nil
+}
+
+// String returns r as the value of an RRULE property.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if !r.Until.IsZero() {
+		if r.untilFloating {
+			parts = append(parts, "UNTIL="+r.Until.Format(toki.LayoutICalendarFloating))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(toki.LayoutICalendar))
+		}
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	ints := func(name string, vs []int) {
+		if len(vs) == 0 {
+			return
+		}
+		s := make([]string, len(vs))
+		for i, v := range vs {
+			s[i] = strconv.Itoa(v)
+		}
+		parts = append(parts, name+"="+strings.Join(s, ","))
+	}
+	ints("BYSECOND", r.BySecond)
+	ints("BYMINUTE", r.ByMinute)
+	ints("BYHOUR", r.ByHour)
+	if len(r.ByDay) > 0 {
+		s := make([]string, len(r.ByDay))
+		for i, w := range r.ByDay {
+			s[i] = w.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(s, ","))
+	}
+	ints("BYMONTHDAY", r.ByMonthDay)
+	ints("BYYEARDAY", r.ByYearDay)
+	ints("BYWEEKNO", r.ByWeekNo)
+	ints("BYMONTH", r.ByMonth)
+	ints("BYSETPOS", r.BySetPos)
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCodes[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
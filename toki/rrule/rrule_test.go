@@ -0,0 +1,71 @@
+package rrule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	r, err := ParseRule("RRULE:FREQ=MONTHLY;INTERVAL=2;BYDAY=1SU,-1SU,MO;BYSETPOS=-1;WKST=SU;COUNT=10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Rule{
+		Freq:      Monthly,
+		Count:     10,
+		Interval:  2,
+		ByDay:     []WeekdayNum{{1, time.Sunday}, {-1, time.Sunday}, {0, time.Monday}},
+		BySetPos:  []int{-1},
+		WeekStart: time.Sunday,
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("ParseRule() = %+v, want %+v", r, want)
+	}
+}
+
+func TestRuleString(t *testing.T) {
+	rules := []string{
+		"FREQ=DAILY",
+		"FREQ=DAILY;UNTIL=19971224T000000Z",
+		"FREQ=HOURLY;UNTIL=19970902T170000;INTERVAL=3",
+		"FREQ=MONTHLY;COUNT=10;INTERVAL=2;BYDAY=1SU,-1SU",
+		"FREQ=YEARLY;BYSECOND=0;BYMINUTE=0,30;BYHOUR=9;BYMONTHDAY=-1;BYYEARDAY=100;BYWEEKNO=20;BYMONTH=1,2;BYSETPOS=1;WKST=SU",
+	}
+	for i, s := range rules {
+		r, err := ParseRule(s)
+		if err != nil {
+			t.Errorf("#%d:: ParseRule(%q) error = %v", i, s, err)
+			continue
+		}
+		if got := r.String(); got != s {
+			t.Errorf("#%d:: String() = %q, want %q", i, got, s)
+		}
+	}
+}
+
+func TestParseRuleErrors(t *testing.T) {
+	rules := []string{
+		"",
+		"COUNT=10",
+		"FREQ=FORTNIGHTLY",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;INTERVAL=-1",
+		"FREQ=DAILY;COUNT=5;UNTIL=19971224T000000Z",
+		"FREQ=DAILY;UNTIL=1997-12-24",
+		"FREQ=DAILY;BYHOUR=24",
+		"FREQ=DAILY;BYMONTHDAY=0",
+		"FREQ=DAILY;BYMONTHDAY=-32",
+		"FREQ=YEARLY;BYWEEKNO=54",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=MONTHLY;BYDAY=1XX",
+		"FREQ=WEEKLY;WKST=MONDAY",
+		"FREQ=DAILY;BYEASTER=0",
+		"FREQ=DAILY;COUNT",
+	}
+	for i, s := range rules {
+		if _, err := ParseRule(s); err == nil {
+			t.Errorf("#%d:: ParseRule(%q) succeeded, want error", i, s)
+		}
+	}
+}
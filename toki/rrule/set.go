@@ -0,0 +1,207 @@
+package rrule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+// A dateTime is a parsed DATE or DATE-TIME value. A floating value, a
+// local time without a TZID, holds its wall clock in UTC until its
+// location is known.
+type dateTime struct {
+	toki.Toki
+	floating bool
+	date     bool
+}
+
+// parseDateTime parses a DATE or DATE-TIME value, local to loc if it is
+// not nil and the value is a local time.
+func parseDateTime(v string, loc *time.Location) (dateTime, error) {
+	d := dateTime{
+		date:     !strings.Contains(v, "T"),
+		floating: loc == nil && !strings.HasSuffix(v, "Z"),
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	t, err := toki.ParseICalendarInLocation(v, loc)
+	if err != nil {
+		return dateTime{}, fmt.Errorf("invalid date-time %q", v)
+	}
+	d.Toki = t
+	return d, nil
+}
+
+// inLocation returns the instant at which the wall clock of loc shows the
+// wall clock of t, with the rules of RFC 5545 for times skipped or
+// repeated by a transition: they are read with the offset before it.
+func inLocation(t toki.Toki, loc *time.Location, layouts ...string) toki.Toki {
+	return toki.TimeOfDayOf(t).On(toki.CivilDateOf(t), loc, layouts...)
+}
+
+// A Set is a recurrence set: the DTSTART of an event, the occurrences of
+// its rules and its RDATEs, less its EXDATEs.
+type Set struct {
+	DTStart toki.Toki
+	RRules  []*Rule
+	RDates  []toki.Toki
+	ExDates []toki.Toki
+}
+
+// Parse parses the DTSTART, RRULE, RDATE and EXDATE content lines of an
+// iCalendar component, one per line, as in
+//
+//	DTSTART;TZID=America/New_York:19970902T090000
+//	RRULE:FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13
+//	EXDATE;TZID=America/New_York:19970902T090000
+//
+// Local times without a TZID are read in the location of DTSTART, which
+// defaults to toki.DefaultLocation. Other lines are ignored.
+func Parse(text string) (*Set, error) {
+	var (
+		s                        Set
+		hasStart                 bool
+		rdates, exdates, pending []*dateTime
+	)
+	loc := toki.DefaultLocation()
+
+	for _, line := range unfold(text) {
+		head, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		params := strings.Split(head, ";")
+		name := strings.ToUpper(params[0])
+		var tzid *time.Location
+		for _, p := range params[1:] {
+			k, v, _ := strings.Cut(p, "=")
+			switch strings.ToUpper(k) {
+			case "TZID":
+				l, err := time.LoadLocation(strings.Trim(v, `"`))
+				if err != nil {
+					return nil, fmt.Errorf("rrule: %s: %w", name, err)
+				}
+				tzid = l
+			case "VALUE":
+				if v = strings.ToUpper(v); v != "DATE" && v != "DATE-TIME" {
+					return nil, fmt.Errorf("rrule: %s: unsupported value type %s", name, v)
+				}
+			}
+		}
+
+		switch name {
+		case "DTSTART":
+			d, err := parseDateTime(value, tzid)
+			if err != nil {
+				return nil, fmt.Errorf("rrule: DTSTART: %w", err)
+			}
+			if d.floating {
+				d.Toki = inLocation(d.Toki, loc)
+			} else {
+				loc = d.Location()
+			}
+			s.DTStart, hasStart = d.Toki, true
+		case "RRULE":
+			r, err := ParseRule(value)
+			if err != nil {
+				return nil, err
+			}
+			s.RRules = append(s.RRules, r)
+		case "RDATE", "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				d, err := parseDateTime(v, tzid)
+				if err != nil {
+					return nil, fmt.Errorf("rrule: %s: %w", name, err)
+				}
+				if name == "RDATE" {
+					rdates = append(rdates, &d)
+				} else {
+					exdates = append(exdates, &d)
+				}
+				if d.floating {
+					pending = append(pending, &d)
+				}
+			}
+		}
+	}
+	if !hasStart {
+		return nil, fmt.Errorf("rrule: missing DTSTART")
+	}
+
+	// Read floating values in the location of DTSTART, which may come
+	// after them.
+	for _, d := range pending {
+		d.Toki = inLocation(d.Toki, loc)
+	}
+	for _, d := range rdates {
+		s.RDates = append(s.RDates, d.Toki)
+	}
+	for _, d := range exdates {
+		s.ExDates = append(s.ExDates, d.Toki)
+	}
+	return &s, nil
+}
+
+// unfold splits text into content lines, joining the continuation lines
+// that start with a space or a tab.
+func unfold(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	return lines
+}
+
+// Between returns the occurrences of s from start, included, to end,
+// excluded, in order. DTSTART is always an occurrence, unless excluded.
+// The occurrences have the location and layout of DTSTART, except for
+// RDATEs, which keep their own.
+func (s *Set) Between(start, end toki.Toki) []toki.Toki {
+	in := func(t toki.Toki) bool {
+		return !t.Before(start) && t.Before(end)
+	}
+	var ts []toki.Toki
+	if in(s.DTStart) {
+		ts = append(ts, s.DTStart)
+	}
+	for _, t := range s.RDates {
+		if in(t) {
+			ts = append(ts, t)
+		}
+	}
+	for _, r := range s.RRules {
+		ts = append(ts, r.Between(s.DTStart, start, end)...)
+	}
+	sort.SliceStable(ts, func(i, j int) bool {
+		return ts[i].Before(ts[j])
+	})
+
+	out := ts[:0]
+	for _, t := range ts {
+		if len(out) > 0 && out[len(out)-1].Equal(t) || s.excluded(t) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+func (s *Set) excluded(t toki.Toki) bool {
+	for _, x := range s.ExDates {
+		if x.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package rrule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+func TestParse(t *testing.T) {
+	ny := newYork(t)
+	// Every Friday the 13th, from RFC 5545, where EXDATE removes DTSTART.
+	s, err := Parse("DTSTART;TZID=America/New_York:19970902T090000\r\n" +
+		"EXDATE;TZID=America/New_York:19970902T090000\r\n" +
+		"RRULE:FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.DTStart.Location().String() != "America/New_York" {
+		t.Errorf("DTSTART in %v, want America/New_York", s.DTStart.Location())
+	}
+	got := s.Between(s.DTStart, parseLocal(t, "20010101T000000", ny))
+	want := "19980213T090000 19980313T090000 19981113T090000 19990813T090000 20001013T090000"
+	if g := strings.Join(formatLocal(got, ny), " "); g != want {
+		t.Errorf("Between() = %s, want %s", g, want)
+	}
+}
+
+func TestSetRDate(t *testing.T) {
+	ny := newYork(t)
+	s, err := Parse(strings.Join([]string{
+		"DTSTART;TZID=America/New_York:20231002T090000",
+		"RRULE:FREQ=WEEKLY;COUNT=4",
+		"RDATE;TZID=America/New_York:20231005T140000,20231010T090000",
+		// Local times without TZID are in the location of DTSTART.
+		"EXDATE:20231016T090000",
+		"RDATE:20231020T130000Z",
+	}, "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := s.Between(s.DTStart, s.DTStart.AddDate(1, 0, 0))
+	// October 10 is both an RDATE and an occurrence of the rule.
+	want := "20231002T090000 20231005T140000 20231009T090000 20231010T090000 20231020T090000 20231023T090000"
+	if g := strings.Join(formatLocal(got, ny), " "); g != want {
+		t.Errorf("Between() = %s, want %s", g, want)
+	}
+}
+
+func TestParseFloating(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	restore := toki.SetDefaultLocation(tokyo)
+	defer restore()
+
+	s, err := Parse("RRULE:FREQ=YEARLY;UNTIL=20251231\nDTSTART;VALUE=DATE:20231231\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := s.Between(s.DTStart, s.DTStart.AddDate(5, 0, 0))
+	want := "20231231T000000 20241231T000000 20251231T000000"
+	if g := strings.Join(formatLocal(got, tokyo), " "); g != want {
+		t.Errorf("Between() = %s, want %s", g, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	texts := []string{
+		"RRULE:FREQ=DAILY",
+		"DTSTART:1997-09-02",
+		"DTSTART;TZID=Nowhere/City:19970902T090000",
+		"DTSTART:19970902T090000\nRRULE:FREQ=NEVER",
+		"DTSTART:19970902T090000\nRDATE;VALUE=PERIOD:19970902T090000Z/PT1H",
+		"DTSTART:19970902T090000\nEXDATE:tomorrow",
+	}
+	for i, text := range texts {
+		if _, err := Parse(text); err == nil {
+			t.Errorf("#%d:: Parse(%q) succeeded, want error", i, text)
+		}
+	}
+}
+
+func TestUnfold(t *testing.T) {
+	got := unfold("RRULE:FREQ=MONTHLY;\r\n BYDAY=FR\r\nDTSTART:19970902T090000\r\n")
+	want := []string{"RRULE:FREQ=MONTHLY;BYDAY=FR", "DTSTART:19970902T090000"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("unfold() = %q, want %q", got, want)
+	}
+}
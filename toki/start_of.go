@@ -0,0 +1,140 @@
+package toki
+
+import (
+	"fmt"
+	"time"
+)
+
+// A Unit is a calendar or clock unit used by StartOf and EndOf.
+type Unit int
+
+const (
+	UnitNanosecond Unit = iota
+	UnitMicrosecond
+	UnitMillisecond
+	UnitSecond
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitQuarter
+	UnitYear
+)
+
+var unitNames = [...]string{
+	UnitNanosecond:  "nanosecond",
+	UnitMicrosecond: "microsecond",
+	UnitMillisecond: "millisecond",
+	UnitSecond:      "second",
+	UnitMinute:      "minute",
+	UnitHour:        "hour",
+	UnitDay:         "day",
+	UnitWeek:        "week",
+	UnitMonth:       "month",
+	UnitQuarter:     "quarter",
+	UnitYear:        "year",
+}
+
+func (u Unit) String() string {
+	if u >= 0 && int(u) < len(unitNames) {
+		return unitNames[u]
+	}
+	return fmt.Sprintf("Unit(%d)", int(u))
+}
+
+// duration returns the length of u if it is a clock unit no longer than
+// an hour, and 0 otherwise.
+func (u Unit) duration() time.Duration {
+	switch u {
+	case UnitNanosecond:
+		return time.Nanosecond
+	case UnitMicrosecond:
+		return time.Microsecond
+	case UnitMillisecond:
+		return time.Millisecond
+	case UnitSecond:
+		return time.Second
+	case UnitMinute:
+		return time.Minute
+	case UnitHour:
+		return time.Hour
+	}
+	return 0
+}
+
+// StartOf returns the first instant of the unit u containing t, computed
+// from the wall clock in t's location rather than from absolute time as
+// Truncate does. Weeks start on weekStart, Monday by default.
+//
+// Days and longer units start at midnight, or at the first instant after
+// midnight when a transition skips it. Clock units cut by a transition
+// start at the transition.
+func (t Toki) StartOf(u Unit, weekStart ...Weekday) Toki {
+	if d := u.duration(); d > 0 {
+		return Toki{layout: t.layout, Time: startOfClock(t.Time, d)}
+	}
+
+	date := CivilDateOf(t)
+	switch u {
+	case UnitDay:
+	case UnitWeek:
+		ws := Monday
+		if len(weekStart) > 0 {
+			ws = weekStart[0]
+		}
+		date = date.AddDays(-((int(date.Weekday()) - int(ws) + 7) % 7))
+	case UnitMonth:
+		date.Day = 1
+	case UnitQuarter:
+		date.Month = (date.Month-1)/3*3 + 1
+		date.Day = 1
+	case UnitYear:
+		date.Month, date.Day = January, 1
+	default:
+		panic("toki: unknown unit " + u.String())
+	}
+	return Toki{layout: t.layout, Time: date.In(t.Location()).Time}
+}
+
+// EndOf returns the last instant of the unit u containing t, one
+// nanosecond before the start of the next one. See StartOf.
+func (t Toki) EndOf(u Unit, weekStart ...Weekday) Toki {
+	start := t.StartOf(u, weekStart...)
+	var next time.Time
+	if d := u.duration(); d > 0 {
+		// The unit may be shortened by a transition, so the next one
+		// starts at the boundary following start.
+		next = startOfClock(start.Time.Add(d), d)
+	} else {
+		date := CivilDateOf(start)
+		switch u {
+		case UnitDay:
+			date = date.AddDays(1)
+		case UnitWeek:
+			date = date.AddDays(7)
+		case UnitMonth:
+			date = date.AddMonths(1)
+		case UnitQuarter:
+			date = date.AddMonths(3)
+		case UnitYear:
+			date = date.AddMonths(12)
+		}
+		next = date.In(t.Location()).Time
+	}
+	return Toki{layout: t.layout, Time: next.Add(-time.Nanosecond)}
+}
+
+// startOfClock truncates the wall clock of t to a multiple of d, which
+// divides an hour.
+func startOfClock(t time.Time, d time.Duration) time.Time {
+	wall := time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second +
+		time.Duration(t.Nanosecond())
+	s := t.Add(-(wall % d))
+	if start, _ := t.ZoneBounds(); !start.IsZero() && s.Before(start) {
+		// The wall-clock boundary lies before the current zone took effect.
+		s = start
+	}
+	return s
+}
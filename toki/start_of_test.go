@@ -0,0 +1,108 @@
+package toki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartOfEndOf(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Monday, October 16, 2023.
+	base := Date(2023, October, 16, 13, 45, 30, 123456789, tokyo)
+
+	tests := [...]struct {
+		t         Toki
+		unit      Unit
+		weekStart []Weekday
+		start     Toki
+		end       Toki
+	}{
+		0: {base, UnitMillisecond, nil, Date(2023, October, 16, 13, 45, 30, 123000000, tokyo), Date(2023, October, 16, 13, 45, 30, 123999999, tokyo)},
+		1: {base, UnitSecond, nil, Date(2023, October, 16, 13, 45, 30, 0, tokyo), Date(2023, October, 16, 13, 45, 30, 999999999, tokyo)},
+		2: {base, UnitMinute, nil, Date(2023, October, 16, 13, 45, 0, 0, tokyo), Date(2023, October, 16, 13, 45, 59, 999999999, tokyo)},
+		3: {base, UnitHour, nil, Date(2023, October, 16, 13, 0, 0, 0, tokyo), Date(2023, October, 16, 13, 59, 59, 999999999, tokyo)},
+		// Truncate(24*time.Hour) would yield 09:00 in Tokyo.
+		4:  {base, UnitDay, nil, Date(2023, October, 16, 0, 0, 0, 0, tokyo), Date(2023, October, 16, 23, 59, 59, 999999999, tokyo)},
+		5:  {base, UnitWeek, nil, Date(2023, October, 16, 0, 0, 0, 0, tokyo), Date(2023, October, 22, 23, 59, 59, 999999999, tokyo)},
+		6:  {base, UnitWeek, []Weekday{Sunday}, Date(2023, October, 15, 0, 0, 0, 0, tokyo), Date(2023, October, 21, 23, 59, 59, 999999999, tokyo)},
+		7:  {base, UnitWeek, []Weekday{Tuesday}, Date(2023, October, 10, 0, 0, 0, 0, tokyo), Date(2023, October, 16, 23, 59, 59, 999999999, tokyo)},
+		8:  {base, UnitMonth, nil, Date(2023, October, 1, 0, 0, 0, 0, tokyo), Date(2023, October, 31, 23, 59, 59, 999999999, tokyo)},
+		9:  {base, UnitQuarter, nil, Date(2023, October, 1, 0, 0, 0, 0, tokyo), Date(2023, December, 31, 23, 59, 59, 999999999, tokyo)},
+		10: {base, UnitYear, nil, Date(2023, January, 1, 0, 0, 0, 0, tokyo), Date(2023, December, 31, 23, 59, 59, 999999999, tokyo)},
+		11: {Date(2024, February, 10, 0, 0, 0, 0, UTC), UnitMonth, nil, Date(2024, February, 1, 0, 0, 0, 0, UTC), Date(2024, February, 29, 23, 59, 59, 999999999, UTC)},
+
+		// The day DST starts in Los Angeles is 23 hours long.
+		12: {Date(2023, March, 12, 12, 0, 0, 0, la), UnitDay, nil, Date(2023, March, 12, 8, 0, 0, 0, UTC), Date(2023, March, 13, 6, 59, 59, 999999999, UTC)},
+		// The day DST ends is 25 hours long.
+		13: {Date(2023, November, 5, 12, 0, 0, 0, la), UnitDay, nil, Date(2023, November, 5, 7, 0, 0, 0, UTC), Date(2023, November, 6, 7, 59, 59, 999999999, UTC)},
+		// 01:30 PST, the second occurrence of 01:30 that day.
+		14: {Date(2023, November, 5, 9, 30, 0, 0, UTC).In(la), UnitHour, nil, Date(2023, November, 5, 9, 0, 0, 0, UTC), Date(2023, November, 5, 9, 59, 59, 999999999, UTC)},
+		// 01:30 PDT, the first occurrence.
+		15: {Date(2023, November, 5, 8, 30, 0, 0, UTC).In(la), UnitHour, nil, Date(2023, November, 5, 8, 0, 0, 0, UTC), Date(2023, November, 5, 8, 59, 59, 999999999, UTC)},
+		16: {Date(2023, March, 12, 3, 30, 0, 0, la), UnitHour, nil, Date(2023, March, 12, 10, 0, 0, 0, UTC), Date(2023, March, 12, 10, 59, 59, 999999999, UTC)},
+	}
+
+	for i, tt := range tests {
+		tt.t.layout = LayoutTimestamp
+		start := tt.t.StartOf(tt.unit, tt.weekStart...)
+		if !start.Equal(tt.start) || start.Location() != tt.t.Location() || start.GetLayout() != LayoutTimestamp {
+			t.Errorf("#%d:: %v.StartOf(%v) = %v, want %v", i, tt.t, tt.unit, start, tt.start)
+		}
+		end := tt.t.EndOf(tt.unit, tt.weekStart...)
+		if !end.Equal(tt.end) || end.Location() != tt.t.Location() || end.GetLayout() != LayoutTimestamp {
+			t.Errorf("#%d:: %v.EndOf(%v) = %v, want %v", i, tt.t, tt.unit, end, tt.end)
+		}
+	}
+}
+
+func TestStartOfSkippedMidnight(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lordHowe, err := time.LoadLocation("Australia/Lord_Howe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clocks went from 00:00 to 01:00 on November 4, 2018.
+	got := Date(2018, November, 4, 12, 0, 0, 0, saoPaulo).StartOf(UnitDay)
+	if want := Date(2018, November, 4, 1, 0, 0, 0, saoPaulo); !got.Equal(want) {
+		t.Errorf("StartOf(UnitDay) = %v, want %v", got, want)
+	}
+	got = Date(2018, November, 3, 12, 0, 0, 0, saoPaulo).EndOf(UnitDay)
+	if want := Date(2018, November, 4, 2, 59, 59, 999999999, UTC); !got.Equal(want) {
+		t.Errorf("EndOf(UnitDay) = %v, want %v", got, want)
+	}
+
+	// Lord Howe Island moves its clocks from 02:00 to 02:30, so the hour
+	// from 02:00 is 30 minutes long.
+	tr := Date(2023, October, 1, 2, 30, 0, 0, lordHowe)
+	if h, m, _ := tr.Clock(); h != 2 || m != 30 {
+		t.Fatalf("unexpected transition %v", tr)
+	}
+	v := tr.Add(15 * time.Minute)
+	if got := v.StartOf(UnitHour); !got.Equal(tr) {
+		t.Errorf("%v.StartOf(UnitHour) = %v, want %v", v, got, tr)
+	}
+	if got, want := v.EndOf(UnitHour), tr.Add(30*time.Minute-time.Nanosecond); !got.Equal(want) {
+		t.Errorf("%v.EndOf(UnitHour) = %v, want %v", v, got, want)
+	}
+}
+
+func TestUnitString(t *testing.T) {
+	if got := UnitQuarter.String(); got != "quarter" {
+		t.Errorf("UnitQuarter.String() = %q", got)
+	}
+	if got := Unit(42).String(); got != "Unit(42)" {
+		t.Errorf("Unit(42).String() = %q", got)
+	}
+}
@@ -0,0 +1,229 @@
+package toki
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const nanosecondsPerDay = int64(24 * time.Hour)
+
+// A TimeOfDay is a wall-clock time without a date or a location, with
+// nanosecond precision. It suits business hours and daily schedules.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// TimeOfDayOf returns the wall-clock time of t in t's location, as
+// decomposed by Clock and Nanosecond.
+func TimeOfDayOf(t Toki) TimeOfDay {
+	h, m, s := t.Clock()
+	return TimeOfDay{Hour: h, Minute: m, Second: s, Nanosecond: t.Nanosecond()}
+}
+
+// ParseTimeOfDay parses a time of day in the HH:MM[:SS[.fffffffff]] format.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	var td TimeOfDay
+	rest := s
+	var ok bool
+	if td.Hour, rest, ok = parseDigits(rest, 2); !ok {
+		return TimeOfDay{}, fmt.Errorf("toki: invalid time of day %q", s)
+	}
+	if len(rest) == 0 || rest[0] != ':' {
+		return TimeOfDay{}, fmt.Errorf("toki: invalid time of day %q", s)
+	}
+	if td.Minute, rest, ok = parseDigits(rest[1:], 2); !ok {
+		return TimeOfDay{}, fmt.Errorf("toki: invalid time of day %q", s)
+	}
+	if len(rest) > 0 && rest[0] == ':' {
+		if td.Second, rest, ok = parseDigits(rest[1:], 2); !ok {
+			return TimeOfDay{}, fmt.Errorf("toki: invalid time of day %q", s)
+		}
+		if len(rest) > 0 && rest[0] == '.' {
+			n := len(rest) - 1
+			if n < 1 || n > 9 {
+				return TimeOfDay{}, fmt.Errorf("toki: invalid time of day %q", s)
+			}
+			if td.Nanosecond, rest, ok = parseDigits(rest[1:], n); !ok {
+				return TimeOfDay{}, fmt.Errorf("toki: invalid time of day %q", s)
+			}
+			for ; n < 9; n++ {
+				td.Nanosecond *= 10
+			}
+		}
+	}
+	if rest != "" || !td.IsValid() {
+		return TimeOfDay{}, fmt.Errorf("toki: invalid time of day %q", s)
+	}
+	return td, nil
+}
+
+// parseDigits parses exactly n leading decimal digits of s.
+func parseDigits(s string, n int) (v int, rest string, ok bool) {
+	if len(s) < n {
+		return 0, s, false
+	}
+	for i := 0; i < n; i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return 0, s, false
+		}
+		v = v*10 + int(c-'0')
+	}
+	return v, s[n:], true
+}
+
+// String returns td in the HH:MM:SS format, followed by the fraction of
+// a second without trailing zeros if it is not zero.
+func (td TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", td.Hour, td.Minute, td.Second)
+	if td.Nanosecond == 0 {
+		return s
+	}
+	f := fmt.Sprintf("%09d", td.Nanosecond)
+	for f[len(f)-1] == '0' {
+		f = f[:len(f)-1]
+	}
+	return s + "." + f
+}
+
+// IsValid reports whether every field of td is within its range.
+func (td TimeOfDay) IsValid() bool {
+	return td.Hour >= 0 && td.Hour < 24 &&
+		td.Minute >= 0 && td.Minute < 60 &&
+		td.Second >= 0 && td.Second < 60 &&
+		td.Nanosecond >= 0 && td.Nanosecond < 1e9
+}
+
+func (td TimeOfDay) IsZero() bool {
+	return td == TimeOfDay{}
+}
+
+// sinceMidnight returns the wall-clock duration from midnight to td.
+func (td TimeOfDay) sinceMidnight() time.Duration {
+	return time.Duration(td.Hour)*time.Hour +
+		time.Duration(td.Minute)*time.Minute +
+		time.Duration(td.Second)*time.Second +
+		time.Duration(td.Nanosecond)
+}
+
+func timeOfDayFromDuration(d time.Duration) TimeOfDay {
+	n := int64(d) % nanosecondsPerDay
+	if n < 0 {
+		n += nanosecondsPerDay
+	}
+	return TimeOfDay{
+		Hour:       int(n / int64(time.Hour)),
+		Minute:     int(n / int64(time.Minute) % 60),
+		Second:     int(n / int64(time.Second) % 60),
+		Nanosecond: int(n % int64(time.Second)),
+	}
+}
+
+func (td TimeOfDay) Before(u TimeOfDay) bool {
+	return td.Compare(u) < 0
+}
+
+func (td TimeOfDay) After(u TimeOfDay) bool {
+	return td.Compare(u) > 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether td is before, equal to
+// or after u.
+func (td TimeOfDay) Compare(u TimeOfDay) int {
+	a, b := td.sinceMidnight(), u.sinceMidnight()
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Add returns td shifted by d, wrapping around midnight, so that 23:00
+// plus two hours is 01:00.
+func (td TimeOfDay) Add(d time.Duration) TimeOfDay {
+	return timeOfDayFromDuration(td.sinceMidnight() + d%time.Duration(nanosecondsPerDay))
+}
+
+// Sub returns the wall-clock duration td-u, which is negative if td is
+// before u. It ignores any transition between the two times.
+func (td TimeOfDay) Sub(u TimeOfDay) time.Duration {
+	return td.sinceMidnight() - u.sinceMidnight()
+}
+
+// On returns the instant at which the wall clock in loc shows td on date.
+// If td does not exist on that date because of a transition, the time is
+// shifted forward by the length of the gap. If td occurs twice, the first
+// occurrence is returned.
+func (td TimeOfDay) On(date CivilDate, loc *Location, layouts ...string) Toki {
+	t := time.Date(date.Year, date.Month, date.Day, td.Hour, td.Minute, td.Second, td.Nanosecond, loc)
+	if h, m, _ := t.Clock(); td.IsValid() && (h != td.Hour || m != td.Minute) {
+		// time.Date resolved a time in a gap using the offset in force
+		// before it, landing before the transition.
+		_, before := t.Zone()
+		_, end := t.ZoneBounds()
+		_, after := end.Zone()
+		t = t.Add(time.Duration(after-before) * time.Second)
+	}
+	return Toki{layout: setLayout(layouts...), Time: t}
+}
+
+func (td TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(td.String()), nil
+}
+
+func (td *TimeOfDay) UnmarshalText(data []byte) error {
+	v, err := ParseTimeOfDay(string(data))
+	if err != nil {
+		return err
+	}
+	*td = v
+	return nil
+}
+
+func (td TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(td.String())
+}
+
+func (td *TimeOfDay) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("TimeOfDay.UnmarshalJSON: %w", err)
+	}
+	return td.UnmarshalText([]byte(s))
+}
+
+// Scan implements the sql.Scanner interface. It accepts TIME columns
+// decoded as time.Time as well as HH:MM:SS strings.
+func (td *TimeOfDay) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*td = TimeOfDay{}
+		return nil
+	case time.Time:
+		*td = TimeOfDayOf(Toki{Time: v})
+		return nil
+	case string:
+		return td.UnmarshalText([]byte(v))
+	case []byte:
+		return td.UnmarshalText(v)
+	}
+	return fmt.Errorf("TimeOfDay.Scan: unsupported type %T", src)
+}
+
+// Value implements the driver.Valuer interface.
+func (td TimeOfDay) Value() (driver.Value, error) {
+	if !td.IsValid() {
+		return nil, fmt.Errorf("TimeOfDay.Value: invalid time of day %s", td)
+	}
+	return td.String(), nil
+}
@@ -0,0 +1,146 @@
+package toki
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	tests := [...]struct {
+		input   string
+		want    TimeOfDay
+		str     string
+		wantErr bool
+	}{
+		0:  {"09:30", TimeOfDay{9, 30, 0, 0}, "09:30:00", false},
+		1:  {"23:59:59", TimeOfDay{23, 59, 59, 0}, "23:59:59", false},
+		2:  {"00:00:00.5", TimeOfDay{0, 0, 0, 500000000}, "00:00:00.5", false},
+		3:  {"12:34:56.000000789", TimeOfDay{12, 34, 56, 789}, "12:34:56.000000789", false},
+		4:  {"24:00", TimeOfDay{}, "", true},
+		5:  {"12:60", TimeOfDay{}, "", true},
+		6:  {"12:00:60", TimeOfDay{}, "", true},
+		7:  {"9:30", TimeOfDay{}, "", true},
+		8:  {"09:30:", TimeOfDay{}, "", true},
+		9:  {"09:30:00.", TimeOfDay{}, "", true},
+		10: {"09:30:00.1234567890", TimeOfDay{}, "", true},
+		11: {"09:30Z", TimeOfDay{}, "", true},
+		12: {"", TimeOfDay{}, "", true},
+	}
+
+	for i, tt := range tests {
+		got, err := ParseTimeOfDay(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("#%d:: ParseTimeOfDay(%q) error = %v, wantErr %t", i, tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("#%d:: ParseTimeOfDay(%q) = %#v, want %#v", i, tt.input, got, tt.want)
+		}
+		if !tt.wantErr && got.String() != tt.str {
+			t.Errorf("#%d:: String() = %q, want %q", i, got.String(), tt.str)
+		}
+	}
+}
+
+func TestTimeOfDayArithmetic(t *testing.T) {
+	tests := [...]struct {
+		td   TimeOfDay
+		d    time.Duration
+		want TimeOfDay
+	}{
+		0: {TimeOfDay{9, 0, 0, 0}, 90 * time.Minute, TimeOfDay{10, 30, 0, 0}},
+		1: {TimeOfDay{23, 0, 0, 0}, 2 * time.Hour, TimeOfDay{1, 0, 0, 0}},
+		2: {TimeOfDay{1, 0, 0, 0}, -2 * time.Hour, TimeOfDay{23, 0, 0, 0}},
+		3: {TimeOfDay{12, 0, 0, 0}, 72 * time.Hour, TimeOfDay{12, 0, 0, 0}},
+		4: {TimeOfDay{0, 0, 0, 0}, -time.Nanosecond, TimeOfDay{23, 59, 59, 999999999}},
+	}
+	for i, tt := range tests {
+		if got := tt.td.Add(tt.d); got != tt.want {
+			t.Errorf("#%d:: %v.Add(%v) = %v, want %v", i, tt.td, tt.d, got, tt.want)
+		}
+	}
+
+	a, b := TimeOfDay{9, 0, 0, 0}, TimeOfDay{17, 30, 0, 0}
+	if got := b.Sub(a); got != 8*time.Hour+30*time.Minute {
+		t.Errorf("%v.Sub(%v) = %v", b, a, got)
+	}
+	if got := a.Sub(b); got != -(8*time.Hour + 30*time.Minute) {
+		t.Errorf("%v.Sub(%v) = %v", a, b, got)
+	}
+	if !a.Before(b) || a.After(b) || a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Errorf("comparison of %v and %v is inconsistent", a, b)
+	}
+}
+
+func TestTimeOfDayOn(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [...]struct {
+		td   TimeOfDay
+		date CivilDate
+		loc  *Location
+		want Toki
+	}{
+		0: {TimeOfDay{9, 30, 0, 0}, CivilDate{2023, October, 16}, UTC, Date(2023, October, 16, 9, 30, 0, 0, UTC)},
+		1: {TimeOfDay{9, 30, 0, 0}, CivilDate{2023, October, 16}, la, Date(2023, October, 16, 16, 30, 0, 0, UTC)},
+		// 02:30 does not exist when DST starts; it is shifted to 03:30 PDT.
+		2: {TimeOfDay{2, 30, 0, 0}, CivilDate{2023, March, 12}, la, Date(2023, March, 12, 10, 30, 0, 0, UTC)},
+		// 01:30 occurs twice when DST ends; the first one is in PDT.
+		3: {TimeOfDay{1, 30, 0, 0}, CivilDate{2023, November, 5}, la, Date(2023, November, 5, 8, 30, 0, 0, UTC)},
+	}
+
+	for i, tt := range tests {
+		got := tt.td.On(tt.date, tt.loc, LayoutTimestamp)
+		if !got.Equal(tt.want) || got.Location() != tt.loc || got.GetLayout() != LayoutTimestamp {
+			t.Errorf("#%d:: %v.On(%v, %v) = %v, want %v", i, tt.td, tt.date, tt.loc, got, tt.want)
+		}
+	}
+
+	now := Date(2023, October, 16, 12, 34, 56, 789, la)
+	if got := TimeOfDayOf(now).On(CivilDateOf(now), la); !got.Equal(now) {
+		t.Errorf("round trip of %v = %v", now, got)
+	}
+}
+
+func TestTimeOfDayEncoding(t *testing.T) {
+	td := TimeOfDay{9, 30, 15, 0}
+
+	b, err := json.Marshal(td)
+	if err != nil || string(b) != `"09:30:15"` {
+		t.Errorf("json.Marshal(%v) = %s, %v", td, b, err)
+	}
+	var got TimeOfDay
+	if err := json.Unmarshal(b, &got); err != nil || got != td {
+		t.Errorf("json.Unmarshal(%s) = %v, %v", b, got, err)
+	}
+	if err := json.Unmarshal([]byte(`"25:00"`), &got); err == nil {
+		t.Errorf("json.Unmarshal of an invalid time error = nil")
+	}
+
+	v, err := td.Value()
+	if err != nil || v != "09:30:15" {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+	if _, err := (TimeOfDay{Hour: 24}).Value(); err == nil {
+		t.Errorf("Value() of an invalid time error = nil")
+	}
+
+	scanTests := []interface{}{
+		"09:30:15",
+		[]byte("09:30:15"),
+		time.Date(0, January, 1, 9, 30, 15, 0, UTC),
+	}
+	for _, src := range scanTests {
+		var got TimeOfDay
+		if err := got.Scan(src); err != nil || got != td {
+			t.Errorf("Scan(%#v) = %v, %v", src, got, err)
+		}
+	}
+	if err := got.Scan(42); err == nil {
+		t.Errorf("Scan(42) error = nil")
+	}
+}
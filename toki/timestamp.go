@@ -36,7 +36,7 @@ func (t *Timestamp) UnmarshalJSON(data []byte) error {
 		return err
 
 	}
-	t.Time = time.Unix(i, 0)
+	t.Time = inDefaultLocation(time.Unix(i, 0))
 	return nil
 }
 
@@ -48,7 +48,7 @@ func (t *Timestamp) UnmarshalText(data []byte) error {
 		return err
 
 	}
-	t.Time = time.Unix(i, 0)
+	t.Time = inDefaultLocation(time.Unix(i, 0))
 	return nil
 }
 
@@ -56,7 +56,7 @@ func NowTimeStamp() Timestamp {
 	ts := Timestamp{
 		Toki{
 			layout: LayoutTimestamp,
-			Time:   time.Now(),
+			Time:   DefaultClock().Now(),
 		},
 	}
 	return ts
@@ -36,7 +36,7 @@ func (t *TimestampMilli) UnmarshalJSON(data []byte) error {
 		return err
 
 	}
-	t.Time = time.UnixMilli(i)
+	t.Time = inDefaultLocation(time.UnixMilli(i))
 	return nil
 }
 
@@ -48,13 +48,13 @@ func (t *TimestampMilli) UnmarshalText(data []byte) error {
 		return err
 
 	}
-	t.Time = time.UnixMilli(i)
+	t.Time = inDefaultLocation(time.UnixMilli(i))
 	return nil
 }
 
 func NowTimeStampMilli() TimestampMilli {
 	ts := TimestampMilli{
-		Time: time.Now(),
+		Time: DefaultClock().Now(),
 	}
 	return ts
 }
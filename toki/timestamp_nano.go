@@ -36,7 +36,7 @@ func (t *TimestampNano) UnmarshalJSON(data []byte) error {
 		return err
 
 	}
-	t.Time = time.Unix(0, i)
+	t.Time = inDefaultLocation(time.Unix(0, i))
 	return nil
 }
 
@@ -48,13 +48,13 @@ func (t *TimestampNano) UnmarshalText(data []byte) error {
 		return err
 
 	}
-	t.Time = time.Unix(0, i)
+	t.Time = inDefaultLocation(time.Unix(0, i))
 	return nil
 }
 
 func NowTimeStampNano() TimestampNano {
 	ts := TimestampNano{
-		Time: time.Now(),
+		Time: DefaultClock().Now(),
 	}
 	return ts
 }
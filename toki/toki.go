@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
-	"fmt"
 	"strconv"
 	"time"
 )
@@ -100,19 +99,19 @@ func New(layouts ...string) Toki {
 }
 
 func Now(layouts ...string) Toki {
-	return Toki{layout: setLayout(layouts...), Time: time.Now()}
+	return nowFrom(DefaultClock(), layouts...)
 }
 
 func Unix(sec int64, nsec int64, layouts ...string) Toki {
-	return Toki{layout: setLayout(layouts...), Time: time.Unix(sec, nsec)}
+	return Toki{layout: setLayout(layouts...), Time: inDefaultLocation(time.Unix(sec, nsec))}
 }
 
 func UnixMilli(msec int64, layouts ...string) Toki {
-	return Toki{layout: setLayout(layouts...), Time: time.UnixMilli(msec)}
+	return Toki{layout: setLayout(layouts...), Time: inDefaultLocation(time.UnixMilli(msec))}
 }
 
 func UnixMicro(usec int64, layouts ...string) Toki {
-	return Toki{layout: setLayout(layouts...), Time: time.UnixMicro(usec)}
+	return Toki{layout: setLayout(layouts...), Time: inDefaultLocation(time.UnixMicro(usec))}
 }
 
 func Date(year int, month Month, day, hour, min, sec, nsec int, loc *time.Location, layouts ...string) Toki {
@@ -131,8 +130,12 @@ func FixedZone(name string, offset int) *Location {
 }
 
 func Parse(layout, value string, layouts ...string) (Toki, error) {
+	if c, ok := lookupLayout(layout); ok {
+		t, err := c.parse(value)
+		return Toki{layout: setLayout(layouts...), Time: t}, err
+	}
 	t, err := time.Parse(layout, value)
-	return Toki{layout: setLayout(layouts...), Time: t}, err
+	return Toki{layout: setLayout(layouts...), Time: localize(t)}, err
 }
 
 func (t Toki) Add(d time.Duration) Toki {
@@ -174,6 +177,10 @@ func (t Toki) Equal(u Toki) bool {
 }
 
 func (t Toki) Format(layout string) string {
+	if c, ok := lookupLayout(layout); ok {
+		s, _ := c.format(t.Time)
+		return s
+	}
 	return t.Time.Format(layout)
 }
 
@@ -189,7 +196,11 @@ func (t Toki) GoString() string {
 }
 
 func (t *Toki) GobDecode(data []byte) error {
-	return t.Time.GobDecode(data)
+	if err := t.Time.GobDecode(data); err != nil {
+		return err
+	}
+	t.Time = localize(t.Time)
+	return nil
 }
 
 func (t Toki) GobEncode() ([]byte, error) {
@@ -204,9 +215,10 @@ func (t Toki) ISOWeek() (year, week int) {
 	return t.Time.ISOWeek()
 }
 
+// In returns t with its location set to loc, keeping its layout. It panics
+// if loc is nil, like time.Time.In.
 func (t Toki) In(loc *time.Location) Toki {
-	_ = t.Time.In(loc)
-
+	t.Time = t.Time.In(loc)
 	return t
 }
 
@@ -218,8 +230,9 @@ func (t Toki) IsZero() bool {
 	return t.Time.IsZero()
 }
 
+// Local returns t in DefaultLocation, keeping its layout.
 func (t Toki) Local() Toki {
-	_ = t.Time.Local()
+	t.Time = t.Time.In(DefaultLocation())
 	return t
 }
 
@@ -248,7 +261,14 @@ func (t Toki) MarshalJSON() ([]byte, error) {
 		i := t.Time.UnixNano()
 		err = binary.Write(buf, binary.BigEndian, i)
 	default:
-		s := t.Time.Format(t.GetLayout())
+		var s string
+		if c, ok := lookupLayout(t.GetLayout()); ok {
+			if s, err = c.format(t.Time); err != nil {
+				return nil, err
+			}
+		} else {
+			s = t.Time.Format(t.GetLayout())
+		}
 		_, err = buf.WriteString(`"` + s + `"`)
 	}
 
@@ -275,7 +295,14 @@ func (t Toki) MarshalText() ([]byte, error) {
 		i := t.Time.UnixNano()
 		err = binary.Write(buf, binary.BigEndian, i)
 	default:
-		s := t.Time.Format(t.GetLayout())
+		var s string
+		if c, ok := lookupLayout(t.GetLayout()); ok {
+			if s, err = c.format(t.Time); err != nil {
+				return nil, err
+			}
+		} else {
+			s = t.Time.Format(t.GetLayout())
+		}
 		_, err = buf.WriteString(s)
 	}
 
@@ -348,14 +375,20 @@ func (t Toki) UnixNano() int64 {
 }
 
 func (t *Toki) UnmarshalBinary(data []byte) error {
-	return t.Time.UnmarshalBinary(data)
+	if err := t.Time.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	t.Time = localize(t.Time)
+	return nil
 }
 
 func (t *Toki) UnmarshalJSON(data []byte) error {
-	fmt.Printf("UnmarshalJSON: GetLayout: %s\n", t.GetLayout())
-
 	if t.GetLayout() == RFC3339 {
-		return t.Time.UnmarshalJSON(data)
+		if err := t.Time.UnmarshalJSON(data); err != nil {
+			return err
+		}
+		t.Time = localize(t.Time)
+		return nil
 	}
 
 	s := string(data)
@@ -368,17 +401,17 @@ func (t *Toki) UnmarshalJSON(data []byte) error {
 	case LayoutTimestamp:
 		var i int64
 		if i, err = strconv.ParseInt(s, 10, 64); err == nil {
-			t.Time = time.Unix(i, 0)
+			t.Time = inDefaultLocation(time.Unix(i, 0))
 		}
 	case LayoutTimestampMilli:
 		var i int64
 		if i, err = strconv.ParseInt(s, 10, 64); err == nil {
-			t.Time = time.UnixMilli(i)
+			t.Time = inDefaultLocation(time.UnixMilli(i))
 		}
 	case LayoutTimestampNano:
 		var i int64
 		if i, err = strconv.ParseInt(s, 10, 64); err == nil {
-			t.Time = time.Unix(0, i)
+			t.Time = inDefaultLocation(time.Unix(0, i))
 		}
 	default:
 		if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
@@ -386,7 +419,11 @@ func (t *Toki) UnmarshalJSON(data []byte) error {
 		}
 		data = data[len(`"`) : len(data)-len(`"`)]
 		s = string(data)
-		t.Time, err = time.Parse(t.GetLayout(), s)
+		if c, ok := lookupLayout(t.GetLayout()); ok {
+			t.Time, err = c.parse(s)
+		} else if t.Time, err = time.Parse(t.GetLayout(), s); err == nil {
+			t.Time = localize(t.Time)
+		}
 	}
 
 	if err != nil {
@@ -400,7 +437,11 @@ func (t *Toki) UnmarshalJSON(data []byte) error {
 
 func (t *Toki) UnmarshalText(data []byte) error {
 	if t.GetLayout() == RFC3339 {
-		return t.Time.UnmarshalText(data)
+		if err := t.Time.UnmarshalText(data); err != nil {
+			return err
+		}
+		t.Time = localize(t.Time)
+		return nil
 	}
 
 	s := string(data)
@@ -410,20 +451,24 @@ func (t *Toki) UnmarshalText(data []byte) error {
 	case LayoutTimestamp:
 		var i int64
 		if i, err = strconv.ParseInt(s, 10, 64); err != nil {
-			t.Time = time.Unix(i, 0)
+			t.Time = inDefaultLocation(time.Unix(i, 0))
 		}
 	case LayoutTimestampMilli:
 		var i int64
 		if i, err = strconv.ParseInt(s, 10, 64); err != nil {
-			t.Time = time.UnixMilli(i)
+			t.Time = inDefaultLocation(time.UnixMilli(i))
 		}
 	case LayoutTimestampNano:
 		var i int64
 		if i, err = strconv.ParseInt(s, 10, 64); err != nil {
-			t.Time = time.Unix(0, i)
+			t.Time = inDefaultLocation(time.Unix(0, i))
 		}
 	default:
-		t.Time, err = time.Parse(t.GetLayout(), s)
+		if c, ok := lookupLayout(t.GetLayout()); ok {
+			t.Time, err = c.parse(s)
+		} else if t.Time, err = time.Parse(t.GetLayout(), s); err == nil {
+			t.Time = localize(t.Time)
+		}
 	}
 
 	if err != nil {
@@ -576,34 +576,34 @@ func dateTests() []struct {
 		z                                      *Location
 		unix                                   int64
 	}{
-		{2011, 11, 6, 1, 0, 0, 0, time.Local, 1320566400},   // 1:00:00 PDT
-		{2011, 11, 6, 1, 59, 59, 0, time.Local, 1320569999}, // 1:59:59 PDT
-		{2011, 11, 6, 2, 0, 0, 0, time.Local, 1320573600},   // 2:00:00 PST
+		{2011, 11, 6, 1, 0, 0, 0, local(), 1320566400},   // 1:00:00 PDT
+		{2011, 11, 6, 1, 59, 59, 0, local(), 1320569999}, // 1:59:59 PDT
+		{2011, 11, 6, 2, 0, 0, 0, local(), 1320573600},   // 2:00:00 PST
 
-		{2011, 3, 13, 1, 0, 0, 0, time.Local, 1300006800},   // 1:00:00 PST
-		{2011, 3, 13, 1, 59, 59, 0, time.Local, 1300010399}, // 1:59:59 PST
-		{2011, 3, 13, 3, 0, 0, 0, time.Local, 1300010400},   // 3:00:00 PDT
-		{2011, 3, 13, 2, 30, 0, 0, time.Local, 1300008600},  // 2:30:00 PDT ≡ 1:30 PST
-		{2012, 12, 24, 0, 0, 0, 0, time.Local, 1356336000},  // Leap year
+		{2011, 3, 13, 1, 0, 0, 0, local(), 1300006800},   // 1:00:00 PST
+		{2011, 3, 13, 1, 59, 59, 0, local(), 1300010399}, // 1:59:59 PST
+		{2011, 3, 13, 3, 0, 0, 0, local(), 1300010400},   // 3:00:00 PDT
+		{2011, 3, 13, 2, 30, 0, 0, local(), 1300008600},  // 2:30:00 PDT ≡ 1:30 PST
+		{2012, 12, 24, 0, 0, 0, 0, local(), 1356336000},  // Leap year
 
 		// Many names for Fri Nov 18 7:56:35 PST 2011
-		{2011, 11, 18, 7, 56, 35, 0, time.Local, 1321631795},                 // Nov 18 7:56:35
-		{2011, 11, 19, -17, 56, 35, 0, time.Local, 1321631795},               // Nov 19 -17:56:35
-		{2011, 11, 17, 31, 56, 35, 0, time.Local, 1321631795},                // Nov 17 31:56:35
-		{2011, 11, 18, 6, 116, 35, 0, time.Local, 1321631795},                // Nov 18 6:116:35
-		{2011, 10, 49, 7, 56, 35, 0, time.Local, 1321631795},                 // Oct 49 7:56:35
-		{2011, 11, 18, 7, 55, 95, 0, time.Local, 1321631795},                 // Nov 18 7:55:95
-		{2011, 11, 18, 7, 56, 34, 1e9, time.Local, 1321631795},               // Nov 18 7:56:34 + 10⁹ns
-		{2011, 12, -12, 7, 56, 35, 0, time.Local, 1321631795},                // Dec -21 7:56:35
-		{2012, 1, -43, 7, 56, 35, 0, time.Local, 1321631795},                 // Jan -52 7:56:35 2012
-		{2012, int(January - 2), 18, 7, 56, 35, 0, time.Local, 1321631795},   // (Jan-2) 18 7:56:35 2012
-		{2010, int(December + 11), 18, 7, 56, 35, 0, time.Local, 1321631795}, // (Dec+11) 18 7:56:35 2010
+		{2011, 11, 18, 7, 56, 35, 0, local(), 1321631795},                 // Nov 18 7:56:35
+		{2011, 11, 19, -17, 56, 35, 0, local(), 1321631795},               // Nov 19 -17:56:35
+		{2011, 11, 17, 31, 56, 35, 0, local(), 1321631795},                // Nov 17 31:56:35
+		{2011, 11, 18, 6, 116, 35, 0, local(), 1321631795},                // Nov 18 6:116:35
+		{2011, 10, 49, 7, 56, 35, 0, local(), 1321631795},                 // Oct 49 7:56:35
+		{2011, 11, 18, 7, 55, 95, 0, local(), 1321631795},                 // Nov 18 7:55:95
+		{2011, 11, 18, 7, 56, 34, 1e9, local(), 1321631795},               // Nov 18 7:56:34 + 10⁹ns
+		{2011, 12, -12, 7, 56, 35, 0, local(), 1321631795},                // Dec -21 7:56:35
+		{2012, 1, -43, 7, 56, 35, 0, local(), 1321631795},                 // Jan -52 7:56:35 2012
+		{2012, int(January - 2), 18, 7, 56, 35, 0, local(), 1321631795},   // (Jan-2) 18 7:56:35 2012
+		{2010, int(December + 11), 18, 7, 56, 35, 0, local(), 1321631795}, // (Dec+11) 18 7:56:35 2010
 	}
 }
 
 func local() *time.Location {
 	ForceUSPacificForTesting()
-	return time.Local
+	return DefaultLocation()
 }
 
 func same(t Toki, u *parsedTime) bool {
@@ -1156,6 +1156,70 @@ func TestSub(t *testing.T) {
 	}
 }
 
+func TestIn(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := Date(2023, October, 16, 1, 15, 0, 0, UTC, time.RFC1123)
+
+	tests := [...]struct {
+		loc  *Location
+		want string
+	}{
+		0: {UTC, "Mon, 16 Oct 2023 01:15:00 UTC"},
+		1: {tokyo, "Mon, 16 Oct 2023 10:15:00 JST"},
+		2: {ny, "Sun, 15 Oct 2023 21:15:00 EDT"},
+		3: {FixedZone("", 5*60*60+30*60), "Mon, 16 Oct 2023 06:45:00 +0530"},
+	}
+
+	for i, tt := range tests {
+		got := v.In(tt.loc)
+		if got.Location() != tt.loc || !got.Equal(v) || got.GetLayout() != time.RFC1123 {
+			t.Errorf("#%d:: In(%v) = %v in %v, want %v in %v", i, tt.loc, got, got.Location(), v, tt.loc)
+		}
+		if s := got.Format(got.GetLayout()); s != tt.want {
+			t.Errorf("#%d:: In(%v).Format() = %q, want %q", i, tt.loc, s, tt.want)
+		}
+		if v.Location() != UTC {
+			t.Fatalf("#%d:: In(%v) modified its receiver", i, tt.loc)
+		}
+	}
+}
+
+func TestLocal(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := Date(2023, October, 16, 1, 15, 0, 0, UTC, time.RFC1123)
+
+	tests := [...]struct {
+		loc  *Location // the default location, time.Local if nil
+		want *Location
+	}{
+		0: {nil, time.Local},
+		1: {tokyo, tokyo},
+		2: {UTC, UTC},
+	}
+
+	for i, tt := range tests {
+		restore := SetDefaultLocation(tt.loc)
+		got := v.Local()
+		restore()
+		if got.Location() != tt.want || !got.Equal(v) || got.GetLayout() != time.RFC1123 {
+			t.Errorf("#%d:: Local() = %v in %v, want %v in %v", i, got, got.Location(), v, tt.want)
+		}
+	}
+	if v.Location() != UTC {
+		t.Errorf("Local() modified its receiver")
+	}
+}
+
 func TestDefaultLoc(t *testing.T) {
 	// Verify that all of Time's methods behave identically if loc is set to
 	// nil or UTC.
@@ -1390,7 +1454,7 @@ func TestZoneBounds(t *testing.T) {
 		14: {Date(1992, September, 15, 0, 50, 0, 0, loc), boundThree, New()},
 
 		// The ZoneBounds of a local time would return two local Time.
-		// Note: We preloaded "America/Los_Angeles" as time.Local for testing
+		// Note: We preloaded "America/Los_Angeles" as the default location for testing
 		15: {makeLocalTime(0), makeLocalTime(-5756400), makeLocalTime(9972000)},
 		16: {makeLocalTime(1221681866), makeLocalTime(1205056800), makeLocalTime(1225616400)},
 		17: {makeLocalTime(2152173599), makeLocalTime(2145916800), makeLocalTime(2152173600)},
@@ -0,0 +1,54 @@
+// Package tokitest provides helpers for tests of code using toki.
+package tokitest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+var (
+	mu      sync.Mutex
+	changed = sync.NewCond(&mu)
+	current *time.Location
+	holders int
+	restore func()
+)
+
+// WithLocation makes loc the default location of toki, used by Local, Now
+// and the Unix functions, until tb and its subtests complete.
+//
+// WithLocation is safe to call from parallel tests: tests asking for the
+// same location share it, while a test asking for another location waits
+// until every test holding the current one has completed. Consequently,
+// a subtest must not ask for a different location than its parent.
+func WithLocation(tb testing.TB, loc *time.Location) {
+	tb.Helper()
+	if loc == nil {
+		tb.Fatal("tokitest: nil location")
+	}
+
+	mu.Lock()
+	for holders > 0 && current != loc {
+		changed.Wait()
+	}
+	if holders == 0 {
+		current = loc
+		restore = toki.SetDefaultLocation(loc)
+	}
+	holders++
+	mu.Unlock()
+
+	tb.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		holders--
+		if holders == 0 {
+			restore()
+			current, restore = nil, nil
+			changed.Broadcast()
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package tokitest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/usk81/toki"
+)
+
+func TestWithLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := toki.DefaultLocation()
+
+	t.Run("group", func(t *testing.T) {
+		for _, loc := range []*time.Location{tokyo, la, tokyo, la} {
+			loc := loc
+			t.Run(loc.String(), func(t *testing.T) {
+				t.Parallel()
+				WithLocation(t, loc)
+
+				for i := 0; i < 100; i++ {
+					if got := toki.DefaultLocation(); got != loc {
+						t.Fatalf("DefaultLocation() = %v, want %v", got, loc)
+					}
+					if got := toki.Unix(0, 0).Location(); got != loc {
+						t.Fatalf("Unix(0, 0).Location() = %v, want %v", got, loc)
+					}
+					if got := toki.Now().Location(); got != loc {
+						t.Fatalf("Now().Location() = %v, want %v", got, loc)
+					}
+					if got := toki.Date(2023, 10, 16, 0, 0, 0, 0, time.UTC).Local().Location(); got != loc {
+						t.Fatalf("Local().Location() = %v, want %v", got, loc)
+					}
+				}
+			})
+		}
+	})
+
+	if got := toki.DefaultLocation(); got != before {
+		t.Errorf("DefaultLocation() after cleanup = %v, want %v", got, before)
+	}
+	if time.Local != before {
+		t.Errorf("time.Local was modified")
+	}
+}
@@ -0,0 +1,198 @@
+package toki
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Layouts of dates in the Japanese era calendar (和暦). They can be used
+// as the layout of a Toki, with Format and with Parse, but not combined
+// with the layout elements of the time package.
+const (
+	// LayoutWareki formats dates like 令和5年10月16日, and the first
+	// year of an era as 元年, like 令和元年5月1日.
+	LayoutWareki = "wareki"
+	// LayoutWarekiFullWidth formats dates like LayoutWareki with
+	// full-width digits, like 令和５年１０月１６日.
+	LayoutWarekiFullWidth = "wareki_fullwidth"
+	// LayoutWarekiKanjiShort formats dates like 令05.10.16.
+	LayoutWarekiKanjiShort = "wareki_kanji_short"
+	// LayoutWarekiShort formats dates like R05.10.16.
+	LayoutWarekiShort = "wareki_short"
+)
+
+// A japaneseEra is an era of the Japanese calendar since the adoption of
+// the one era per reign system.
+type japaneseEra struct {
+	name   string
+	abbr   string
+	letter string
+	start  CivilDate
+}
+
+var japaneseEras = [...]japaneseEra{
+	{"明治", "明", "M", CivilDate{1868, October, 23}},
+	{"大正", "大", "T", CivilDate{1912, July, 30}},
+	{"昭和", "昭", "S", CivilDate{1926, December, 25}},
+	{"平成", "平", "H", CivilDate{1989, January, 8}},
+	{"令和", "令", "R", CivilDate{2019, May, 1}},
+}
+
+func init() {
+	for _, layout := range []string{LayoutWareki, LayoutWarekiFullWidth, LayoutWarekiKanjiShort, LayoutWarekiShort} {
+		layout := layout
+		layoutCodecs[layout] = layoutCodec{
+			format: func(t time.Time) (string, error) { return formatWareki(t, layout) },
+			parse:  parseWareki,
+		}
+	}
+}
+
+// eraOf returns the index in japaneseEras of the era of d, or -1 if d is
+// before the Meiji era.
+func eraOf(d CivilDate) int {
+	for i := len(japaneseEras) - 1; i >= 0; i-- {
+		if !d.Before(japaneseEras[i].start) {
+			return i
+		}
+	}
+	return -1
+}
+
+// formatWareki formats the date of t in layout. Dates before the Meiji
+// era are formatted with their Gregorian year, along with an error.
+func formatWareki(t time.Time, layout string) (string, error) {
+	d := CivilDateOf(Toki{Time: t})
+	i := eraOf(d)
+	var err error
+	var era japaneseEra
+	year := d.Year
+	if i < 0 {
+		err = fmt.Errorf("toki: %s is before the Meiji era", d)
+	} else {
+		era = japaneseEras[i]
+		year = d.Year - era.start.Year + 1
+	}
+
+	switch layout {
+	case LayoutWarekiKanjiShort:
+		return fmt.Sprintf("%s%02d.%02d.%02d", era.abbr, year, int(d.Month), d.Day), err
+	case LayoutWarekiShort:
+		return fmt.Sprintf("%s%02d.%02d.%02d", era.letter, year, int(d.Month), d.Day), err
+	}
+	y := strconv.Itoa(year)
+	if year == 1 && i >= 0 {
+		y = "元"
+	}
+	s := fmt.Sprintf("%s%s年%d月%d日", era.name, y, int(d.Month), d.Day)
+	if layout == LayoutWarekiFullWidth {
+		s = strings.Map(toFullWidthDigit, s)
+	}
+	return s, err
+}
+
+func toFullWidthDigit(r rune) rune {
+	if r >= '0' && r <= '9' {
+		return r - '0' + '０'
+	}
+	return r
+}
+
+func toHalfWidthDigit(r rune) rune {
+	if r >= '０' && r <= '９' {
+		return r - '０' + '0'
+	}
+	return r
+}
+
+// parseWareki parses a date in any of the wareki layouts, at midnight
+// UTC like time.Parse. It accepts era names, kanji and alphabetic
+// abbreviations, full-width digits and 元 for the first year, and checks
+// that the date lies within the era.
+func parseWareki(value string) (time.Time, error) {
+	bad := func() (time.Time, error) {
+		return time.Time{}, fmt.Errorf("toki: cannot parse %q as a Japanese era date", value)
+	}
+	s := strings.Map(toHalfWidthDigit, value)
+
+	i := -1
+	for j, era := range japaneseEras {
+		for _, prefix := range []string{era.name, era.abbr, era.letter, strings.ToLower(era.letter)} {
+			if strings.HasPrefix(s, prefix) {
+				i, s = j, s[len(prefix):]
+				break
+			}
+		}
+		if i >= 0 {
+			break
+		}
+	}
+	if i < 0 {
+		return bad()
+	}
+
+	var year int
+	if strings.HasPrefix(s, "元") {
+		year, s = 1, s[len("元"):]
+	} else if year, s = leadingInt(s); year < 1 {
+		return bad()
+	}
+
+	// Either 年, 月 and 日, or a separator between the fields.
+	kanji := strings.HasPrefix(s, "年")
+	var sep string
+	if kanji {
+		s = s[len("年"):]
+	} else {
+		r, n := utf8.DecodeRuneInString(s)
+		if r != '.' && r != '/' && r != '-' {
+			return bad()
+		}
+		sep, s = s[:n], s[n:]
+	}
+	month, s := leadingInt(s)
+	if kanji {
+		if !strings.HasPrefix(s, "月") {
+			return bad()
+		}
+		s = s[len("月"):]
+	} else {
+		if !strings.HasPrefix(s, sep) {
+			return bad()
+		}
+		s = s[len(sep):]
+	}
+	day, s := leadingInt(s)
+	if kanji {
+		s = strings.TrimPrefix(s, "日")
+	}
+	if s != "" {
+		return bad()
+	}
+
+	era := japaneseEras[i]
+	d := CivilDate{Year: era.start.Year + year - 1, Month: Month(month), Day: day}
+	if !d.IsValid() {
+		return bad()
+	}
+	if eraOf(d) != i {
+		return time.Time{}, fmt.Errorf("toki: %q is outside the %s era", value, era.name)
+	}
+	return d.In(UTC).Time, nil
+}
+
+// leadingInt consumes up to four leading decimal digits of s. It returns
+// -1 if there are none.
+func leadingInt(s string) (int, string) {
+	n, i := 0, 0
+	for ; i < len(s) && i < 4 && s[i] >= '0' && s[i] <= '9'; i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	if i == 0 {
+		return -1, s
+	}
+	return n, s[i:]
+}
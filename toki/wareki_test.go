@@ -0,0 +1,118 @@
+package toki
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFormatWareki(t *testing.T) {
+	tests := [...]struct {
+		date   CivilDate
+		layout string
+		want   string
+	}{
+		0:  {CivilDate{2023, October, 16}, LayoutWareki, "令和5年10月16日"},
+		1:  {CivilDate{2023, October, 16}, LayoutWarekiFullWidth, "令和５年１０月１６日"},
+		2:  {CivilDate{2023, October, 16}, LayoutWarekiKanjiShort, "令05.10.16"},
+		3:  {CivilDate{2023, October, 16}, LayoutWarekiShort, "R05.10.16"},
+		4:  {CivilDate{2019, May, 1}, LayoutWareki, "令和元年5月1日"},
+		5:  {CivilDate{2019, May, 1}, LayoutWarekiFullWidth, "令和元年５月１日"},
+		6:  {CivilDate{2019, May, 1}, LayoutWarekiShort, "R01.05.01"},
+		7:  {CivilDate{2019, April, 30}, LayoutWareki, "平成31年4月30日"},
+		8:  {CivilDate{1989, January, 7}, LayoutWareki, "昭和64年1月7日"},
+		9:  {CivilDate{1989, January, 8}, LayoutWarekiShort, "H01.01.08"},
+		10: {CivilDate{1926, December, 24}, LayoutWarekiShort, "T15.12.24"},
+		11: {CivilDate{1926, December, 25}, LayoutWareki, "昭和元年12月25日"},
+		12: {CivilDate{1912, July, 29}, LayoutWarekiKanjiShort, "明45.07.29"},
+		13: {CivilDate{1912, July, 30}, LayoutWareki, "大正元年7月30日"},
+		14: {CivilDate{1868, October, 23}, LayoutWareki, "明治元年10月23日"},
+		// Before the Meiji era, the Gregorian year is used.
+		15: {CivilDate{1868, October, 22}, LayoutWareki, "1868年10月22日"},
+	}
+
+	for i, tt := range tests {
+		v := tt.date.In(UTC)
+		if got := v.Format(tt.layout); got != tt.want {
+			t.Errorf("#%d:: Format(%s) = %q, want %q", i, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestParseWareki(t *testing.T) {
+	tests := [...]struct {
+		layout  string
+		value   string
+		want    CivilDate
+		wantErr bool
+	}{
+		0: {LayoutWareki, "令和5年10月16日", CivilDate{2023, October, 16}, false},
+		1: {LayoutWareki, "令和元年5月1日", CivilDate{2019, May, 1}, false},
+		2: {LayoutWareki, "令和1年5月1日", CivilDate{2019, May, 1}, false},
+		3: {LayoutWarekiFullWidth, "令和５年１０月１６日", CivilDate{2023, October, 16}, false},
+		4: {LayoutWarekiShort, "R05.10.16", CivilDate{2023, October, 16}, false},
+		5: {LayoutWarekiShort, "r5/10/16", CivilDate{2023, October, 16}, false},
+		6: {LayoutWarekiKanjiShort, "令05.10.16", CivilDate{2023, October, 16}, false},
+		7: {LayoutWarekiShort, "S64.01.07", CivilDate{1989, January, 7}, false},
+		8: {LayoutWarekiShort, "M01.10.23", CivilDate{1868, October, 23}, false},
+		9: {LayoutWareki, "平成31年4月30日", CivilDate{2019, April, 30}, false},
+		// Outside the era.
+		10: {LayoutWareki, "平成31年5月1日", CivilDate{}, true},
+		11: {LayoutWarekiShort, "S64.01.08", CivilDate{}, true},
+		12: {LayoutWarekiShort, "R01.04.30", CivilDate{}, true},
+		// Malformed.
+		13: {LayoutWareki, "令和5年2月30日", CivilDate{}, true},
+		14: {LayoutWareki, "令和5年10月", CivilDate{}, true},
+		15: {LayoutWarekiShort, "R05.10-16", CivilDate{}, true},
+		16: {LayoutWarekiShort, "X05.10.16", CivilDate{}, true},
+		17: {LayoutWarekiShort, "R05.10.16 ", CivilDate{}, true},
+		18: {LayoutWareki, "令和0年1月1日", CivilDate{}, true},
+	}
+
+	for i, tt := range tests {
+		got, err := Parse(tt.layout, tt.value, tt.layout)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("#%d:: Parse(%s, %q) error = %v, wantErr %t", i, tt.layout, tt.value, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if d := CivilDateOf(got); d != tt.want || got.Location() != UTC || got.GetLayout() != tt.layout {
+			t.Errorf("#%d:: Parse(%s, %q) = %v, want %v", i, tt.layout, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWarekiMarshal(t *testing.T) {
+	type form struct {
+		Date Toki `json:"date"`
+	}
+
+	v := form{Date: Date(2023, October, 16, 0, 0, 0, 0, UTC, LayoutWareki)}
+	b, err := json.Marshal(v)
+	if want := `{"date":"令和5年10月16日"}`; err != nil || string(b) != want {
+		t.Fatalf("json.Marshal() = %s, %v, want %s", b, err, want)
+	}
+
+	got := form{Date: New(LayoutWarekiShort)}
+	if err := json.Unmarshal([]byte(`{"date":"R05.10.16"}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Date.Equal(v.Date) {
+		t.Errorf("json.Unmarshal() = %v, want %v", got.Date, v.Date)
+	}
+
+	text, err := v.Date.MarshalText()
+	if err != nil || string(text) != "令和5年10月16日" {
+		t.Errorf("MarshalText() = %s, %v", text, err)
+	}
+	u := New(LayoutWareki)
+	if err := u.UnmarshalText(text); err != nil || !u.Equal(v.Date) {
+		t.Errorf("UnmarshalText(%s) = %v, %v", text, u, err)
+	}
+
+	early := Date(1850, January, 1, 0, 0, 0, 0, UTC, LayoutWareki)
+	if _, err := early.MarshalJSON(); err == nil {
+		t.Errorf("MarshalJSON() before the Meiji era error = nil")
+	}
+}
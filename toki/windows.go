@@ -0,0 +1,123 @@
+package toki
+
+import (
+	_ "embed"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultTerritory is the CLDR territory holding the golden zone of each
+// Windows time zone.
+const defaultTerritory = "001"
+
+//go:embed data/windowsZones.xml
+var windowsZonesXML []byte
+
+type windowsZoneTable struct {
+	// toIANA maps a Windows zone name and a territory to IANA zone names.
+	toIANA map[string]map[string][]string
+	// toWindows maps an IANA zone name to a Windows zone name.
+	toWindows map[string]string
+}
+
+var (
+	windowsZonesOnce sync.Once
+	windowsZones     windowsZoneTable
+)
+
+// ianaAliases maps current IANA zone names to the backward-compatible
+// names used by CLDR.
+var ianaAliases = map[string]string{
+	"America/Argentina/Buenos_Aires": "America/Buenos_Aires",
+	"America/Argentina/Catamarca":    "America/Catamarca",
+	"America/Argentina/Cordoba":      "America/Cordoba",
+	"America/Argentina/Jujuy":        "America/Jujuy",
+	"America/Argentina/Mendoza":      "America/Mendoza",
+	"America/Atikokan":               "America/Coral_Harbour",
+	"America/Indiana/Indianapolis":   "America/Indianapolis",
+	"America/Kentucky/Louisville":    "America/Louisville",
+	"America/Nuuk":                   "America/Godthab",
+	"Asia/Ho_Chi_Minh":               "Asia/Saigon",
+	"Asia/Kathmandu":                 "Asia/Katmandu",
+	"Asia/Kolkata":                   "Asia/Calcutta",
+	"Asia/Yangon":                    "Asia/Rangoon",
+	"Atlantic/Faroe":                 "Atlantic/Faeroe",
+	"Europe/Kyiv":                    "Europe/Kiev",
+	"Pacific/Chuuk":                  "Pacific/Truk",
+	"Pacific/Kanton":                 "Pacific/Enderbury",
+	"Pacific/Pohnpei":                "Pacific/Ponape",
+	"UTC":                            "Etc/UTC",
+	"Etc/UCT":                        "Etc/UTC",
+	"Etc/Universal":                  "Etc/UTC",
+	"Etc/Zulu":                       "Etc/UTC",
+}
+
+func loadWindowsZones() windowsZoneTable {
+	windowsZonesOnce.Do(func() {
+		var data struct {
+			MapZones []struct {
+				Other     string `xml:"other,attr"`
+				Territory string `xml:"territory,attr"`
+				Type      string `xml:"type,attr"`
+			} `xml:"windowsZones>mapTimezones>mapZone"`
+		}
+		if err := xml.Unmarshal(windowsZonesXML, &data); err != nil {
+			panic("toki: malformed windowsZones.xml: " + err.Error())
+		}
+
+		windowsZones.toIANA = make(map[string]map[string][]string)
+		windowsZones.toWindows = make(map[string]string)
+		for _, z := range data.MapZones {
+			zones := strings.Fields(z.Type)
+			ts, ok := windowsZones.toIANA[z.Other]
+			if !ok {
+				ts = make(map[string][]string)
+				windowsZones.toIANA[z.Other] = ts
+			}
+			ts[z.Territory] = zones
+			for _, name := range zones {
+				if _, ok := windowsZones.toWindows[name]; !ok {
+					windowsZones.toWindows[name] = z.Other
+				}
+			}
+		}
+	})
+	return windowsZones
+}
+
+// LoadWindowsLocation returns the Location for a Windows time zone name
+// such as "Tokyo Standard Time".
+//
+// territory is an ISO 3166 region code such as "US" or "CA" that selects
+// the zone used for that region. If territory is empty or has no mapping
+// of its own, the CLDR golden zone ("001") is used.
+func LoadWindowsLocation(name, territory string) (*Location, error) {
+	ts, ok := loadWindowsZones().toIANA[name]
+	if !ok {
+		return nil, fmt.Errorf("toki: unknown Windows time zone %q", name)
+	}
+	zones, ok := ts[strings.ToUpper(territory)]
+	if !ok {
+		zones = ts[defaultTerritory]
+	}
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("toki: no IANA time zone for Windows time zone %q", name)
+	}
+	return time.LoadLocation(zones[0])
+}
+
+// WindowsZoneName returns the Windows time zone name for loc, such as
+// "Pacific Standard Time" for America/Los_Angeles.
+func WindowsZoneName(loc *Location) (string, error) {
+	name := loc.String()
+	if alias, ok := ianaAliases[name]; ok {
+		name = alias
+	}
+	if w, ok := loadWindowsZones().toWindows[name]; ok {
+		return w, nil
+	}
+	return "", fmt.Errorf("toki: no Windows time zone for %q", loc.String())
+}
@@ -0,0 +1,91 @@
+package toki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadWindowsLocation(t *testing.T) {
+	tests := [...]struct {
+		name, territory string
+		want            string
+	}{
+		0: {"Tokyo Standard Time", "", "Asia/Tokyo"},
+		1: {"Pacific Standard Time", "", "America/Los_Angeles"},
+		2: {"Pacific Standard Time", "CA", "America/Vancouver"},
+		3: {"Pacific Standard Time", "ca", "America/Vancouver"},
+		4: {"Pacific Standard Time", "JP", "America/Los_Angeles"},
+		5: {"Eastern Standard Time", "001", "America/New_York"},
+		6: {"W. Europe Standard Time", "IT", "Europe/Rome"},
+		7: {"India Standard Time", "", "Asia/Calcutta"},
+		8: {"UTC", "", "Etc/UTC"},
+	}
+
+	for i, tt := range tests {
+		loc, err := LoadWindowsLocation(tt.name, tt.territory)
+		if err != nil {
+			t.Errorf("#%d:: LoadWindowsLocation(%q, %q) error = %v", i, tt.name, tt.territory, err)
+			continue
+		}
+		if loc.String() != tt.want {
+			t.Errorf("#%d:: LoadWindowsLocation(%q, %q) = %v, want %v", i, tt.name, tt.territory, loc, tt.want)
+		}
+	}
+
+	if _, err := LoadWindowsLocation("Mars Standard Time", ""); err == nil {
+		t.Errorf("LoadWindowsLocation(%q) error = nil, want error", "Mars Standard Time")
+	}
+}
+
+func TestWindowsZoneName(t *testing.T) {
+	tests := [...]struct {
+		name string
+		want string
+	}{
+		0: {"Asia/Tokyo", "Tokyo Standard Time"},
+		1: {"America/Los_Angeles", "Pacific Standard Time"},
+		2: {"America/Vancouver", "Pacific Standard Time"},
+		3: {"Europe/Berlin", "W. Europe Standard Time"},
+		4: {"Asia/Kolkata", "India Standard Time"},
+		5: {"Europe/Kyiv", "FLE Standard Time"},
+		6: {"UTC", "UTC"},
+	}
+
+	for i, tt := range tests {
+		loc, err := time.LoadLocation(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := WindowsZoneName(loc)
+		if err != nil {
+			t.Errorf("#%d:: WindowsZoneName(%v) error = %v", i, loc, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("#%d:: WindowsZoneName(%v) = %q, want %q", i, loc, got, tt.want)
+		}
+	}
+
+	if _, err := WindowsZoneName(FixedZone("X", 3600)); err == nil {
+		t.Errorf("WindowsZoneName of a fixed zone error = nil, want error")
+	}
+}
+
+func TestWindowsLocationRoundTrip(t *testing.T) {
+	for name := range loadWindowsZones().toIANA {
+		loc, err := LoadWindowsLocation(name, "")
+		if err != nil {
+			t.Errorf("LoadWindowsLocation(%q) error = %v", name, err)
+			continue
+		}
+		if got, err := WindowsZoneName(loc); err != nil || got != name {
+			t.Errorf("WindowsZoneName(LoadWindowsLocation(%q)) = %q, %v", name, got, err)
+		}
+
+		// The loaded location can be used as any other location.
+		tk := Date(2023, October, 16, 10, 15, 0, 0, UTC).In(loc)
+		if tk.Location() != loc {
+			t.Errorf("Toki.In(%v).Location() = %v", loc, tk.Location())
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package toki
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A YearMonth is a month of a year, such as 2023-10, without a location.
+type YearMonth struct {
+	Year  int
+	Month Month
+}
+
+// A YearQuarter is a calendar quarter of a year, such as 2023-Q4, without
+// a location. Quarter is in the range [1, 4].
+type YearQuarter struct {
+	Year    int
+	Quarter int
+}
+
+// YearMonthOf returns the month of t in t's location.
+func YearMonthOf(t Toki) YearMonth {
+	return YearMonth{Year: t.Year(), Month: t.Month()}
+}
+
+// YearQuarterOf returns the quarter of t in t's location.
+func YearQuarterOf(t Toki) YearQuarter {
+	return YearMonthOf(t).Quarter()
+}
+
+// ParseYearMonth parses a month in the YYYY-MM format.
+func ParseYearMonth(s string) (YearMonth, error) {
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return YearMonth{}, err
+	}
+	return YearMonth{Year: t.Year(), Month: t.Month()}, nil
+}
+
+// ParseYearQuarter parses a quarter in the YYYY-Qn format.
+func ParseYearQuarter(s string) (YearQuarter, error) {
+	if len(s) != 7 || s[4] != '-' || s[5] != 'Q' {
+		return YearQuarter{}, fmt.Errorf("toki: invalid quarter %q", s)
+	}
+	y, _, ok := parseDigits(s, 4)
+	if !ok {
+		return YearQuarter{}, fmt.Errorf("toki: invalid quarter %q", s)
+	}
+	q, _, ok := parseDigits(s[6:], 1)
+	if !ok || q < 1 || q > 4 {
+		return YearQuarter{}, fmt.Errorf("toki: invalid quarter %q", s)
+	}
+	return YearQuarter{Year: y, Quarter: q}, nil
+}
+
+func (ym YearMonth) String() string {
+	return fmt.Sprintf("%04d-%02d", ym.Year, int(ym.Month))
+}
+
+func (ym YearMonth) IsValid() bool {
+	return ym.Month >= January && ym.Month <= December
+}
+
+// Quarter returns the quarter containing ym.
+func (ym YearMonth) Quarter() YearQuarter {
+	return YearQuarter{Year: ym.Year, Quarter: (int(ym.Month)-1)/3 + 1}
+}
+
+// AddMonths returns ym shifted by n months.
+func (ym YearMonth) AddMonths(n int) YearMonth {
+	m := ym.Year*12 + int(ym.Month) - 1 + n
+	y := int(floorDiv(int64(m), 12))
+	return YearMonth{Year: y, Month: Month(m-y*12) + 1}
+}
+
+func (ym YearMonth) Next() YearMonth {
+	return ym.AddMonths(1)
+}
+
+func (ym YearMonth) Prev() YearMonth {
+	return ym.AddMonths(-1)
+}
+
+// Days returns the number of days in ym.
+func (ym YearMonth) Days() int {
+	return DaysIn(ym.Month, ym.Year)
+}
+
+// FirstDay returns the first day of ym.
+func (ym YearMonth) FirstDay() CivilDate {
+	return CivilDate{Year: ym.Year, Month: ym.Month, Day: 1}
+}
+
+// LastDay returns the last day of ym.
+func (ym YearMonth) LastDay() CivilDate {
+	return CivilDate{Year: ym.Year, Month: ym.Month, Day: ym.Days()}
+}
+
+// Start returns the first instant of ym in loc.
+func (ym YearMonth) Start(loc *Location, layouts ...string) Toki {
+	return ym.FirstDay().In(loc, layouts...)
+}
+
+// End returns the last instant of ym in loc, one nanosecond before the
+// start of the next month.
+func (ym YearMonth) End(loc *Location, layouts ...string) Toki {
+	return ym.Next().Start(loc, layouts...).Add(-time.Nanosecond)
+}
+
+func (ym YearMonth) Before(u YearMonth) bool {
+	return ym.Compare(u) < 0
+}
+
+func (ym YearMonth) After(u YearMonth) bool {
+	return ym.Compare(u) > 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether ym is before, equal to
+// or after u.
+func (ym YearMonth) Compare(u YearMonth) int {
+	if ym.Year != u.Year {
+		return cmpInt(ym.Year, u.Year)
+	}
+	return cmpInt(int(ym.Month), int(u.Month))
+}
+
+func (ym YearMonth) MarshalText() ([]byte, error) {
+	return []byte(ym.String()), nil
+}
+
+func (ym *YearMonth) UnmarshalText(data []byte) error {
+	v, err := ParseYearMonth(string(data))
+	if err != nil {
+		return err
+	}
+	*ym = v
+	return nil
+}
+
+func (ym YearMonth) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ym.String())
+}
+
+func (ym *YearMonth) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("YearMonth.UnmarshalJSON: %w", err)
+	}
+	return ym.UnmarshalText([]byte(s))
+}
+
+// Scan implements the sql.Scanner interface. It accepts YYYY-MM strings as
+// well as DATE columns decoded as time.Time.
+func (ym *YearMonth) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*ym = YearMonth{}
+		return nil
+	case time.Time:
+		*ym = YearMonth{Year: v.Year(), Month: v.Month()}
+		return nil
+	case string:
+		return ym.UnmarshalText([]byte(v))
+	case []byte:
+		return ym.UnmarshalText(v)
+	}
+	return fmt.Errorf("YearMonth.Scan: unsupported type %T", src)
+}
+
+// Value implements the driver.Valuer interface. The zero YearMonth is
+// NULL, like Scan reads it.
+func (ym YearMonth) Value() (driver.Value, error) {
+	if ym == (YearMonth{}) {
+		return nil, nil
+	}
+	if !ym.IsValid() {
+		return nil, fmt.Errorf("YearMonth.Value: invalid month %s", ym)
+	}
+	return ym.String(), nil
+}
+
+func (yq YearQuarter) String() string {
+	return fmt.Sprintf("%04d-Q%d", yq.Year, yq.Quarter)
+}
+
+func (yq YearQuarter) IsValid() bool {
+	return yq.Quarter >= 1 && yq.Quarter <= 4
+}
+
+// FirstMonth returns the first month of yq.
+func (yq YearQuarter) FirstMonth() YearMonth {
+	return YearMonth{Year: yq.Year, Month: Month(yq.Quarter*3 - 2)}
+}
+
+// LastMonth returns the last month of yq.
+func (yq YearQuarter) LastMonth() YearMonth {
+	return YearMonth{Year: yq.Year, Month: Month(yq.Quarter * 3)}
+}
+
+// AddQuarters returns yq shifted by n quarters.
+func (yq YearQuarter) AddQuarters(n int) YearQuarter {
+	return yq.FirstMonth().AddMonths(n * 3).Quarter()
+}
+
+func (yq YearQuarter) Next() YearQuarter {
+	return yq.AddQuarters(1)
+}
+
+func (yq YearQuarter) Prev() YearQuarter {
+	return yq.AddQuarters(-1)
+}
+
+// Days returns the number of days in yq.
+func (yq YearQuarter) Days() int {
+	n := 0
+	for ym := yq.FirstMonth(); ym.Compare(yq.LastMonth()) <= 0; ym = ym.Next() {
+		n += ym.Days()
+	}
+	return n
+}
+
+// Start returns the first instant of yq in loc.
+func (yq YearQuarter) Start(loc *Location, layouts ...string) Toki {
+	return yq.FirstMonth().Start(loc, layouts...)
+}
+
+// End returns the last instant of yq in loc, one nanosecond before the
+// start of the next quarter.
+func (yq YearQuarter) End(loc *Location, layouts ...string) Toki {
+	return yq.LastMonth().End(loc, layouts...)
+}
+
+func (yq YearQuarter) Before(u YearQuarter) bool {
+	return yq.Compare(u) < 0
+}
+
+func (yq YearQuarter) After(u YearQuarter) bool {
+	return yq.Compare(u) > 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether yq is before, equal to
+// or after u.
+func (yq YearQuarter) Compare(u YearQuarter) int {
+	if yq.Year != u.Year {
+		return cmpInt(yq.Year, u.Year)
+	}
+	return cmpInt(yq.Quarter, u.Quarter)
+}
+
+func (yq YearQuarter) MarshalText() ([]byte, error) {
+	return []byte(yq.String()), nil
+}
+
+func (yq *YearQuarter) UnmarshalText(data []byte) error {
+	v, err := ParseYearQuarter(string(data))
+	if err != nil {
+		return err
+	}
+	*yq = v
+	return nil
+}
+
+func (yq YearQuarter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(yq.String())
+}
+
+func (yq *YearQuarter) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("YearQuarter.UnmarshalJSON: %w", err)
+	}
+	return yq.UnmarshalText([]byte(s))
+}
+
+// Scan implements the sql.Scanner interface. It accepts YYYY-Qn strings as
+// well as DATE columns decoded as time.Time.
+func (yq *YearQuarter) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*yq = YearQuarter{}
+		return nil
+	case time.Time:
+		*yq = YearMonth{Year: v.Year(), Month: v.Month()}.Quarter()
+		return nil
+	case string:
+		return yq.UnmarshalText([]byte(v))
+	case []byte:
+		return yq.UnmarshalText(v)
+	}
+	return fmt.Errorf("YearQuarter.Scan: unsupported type %T", src)
+}
+
+// Value implements the driver.Valuer interface. The zero YearQuarter is
+// NULL, like Scan reads it.
+func (yq YearQuarter) Value() (driver.Value, error) {
+	if yq == (YearQuarter{}) {
+		return nil, nil
+	}
+	if !yq.IsValid() {
+		return nil, fmt.Errorf("YearQuarter.Value: invalid quarter %s", yq)
+	}
+	return yq.String(), nil
+}
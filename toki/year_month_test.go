@@ -0,0 +1,194 @@
+package toki
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestYearMonth(t *testing.T) {
+	tests := [...]struct {
+		ym      YearMonth
+		next    YearMonth
+		prev    YearMonth
+		days    int
+		quarter YearQuarter
+	}{
+		0: {YearMonth{2023, October}, YearMonth{2023, November}, YearMonth{2023, September}, 31, YearQuarter{2023, 4}},
+		1: {YearMonth{2023, December}, YearMonth{2024, January}, YearMonth{2023, November}, 31, YearQuarter{2023, 4}},
+		2: {YearMonth{2024, January}, YearMonth{2024, February}, YearMonth{2023, December}, 31, YearQuarter{2024, 1}},
+		3: {YearMonth{2024, February}, YearMonth{2024, March}, YearMonth{2024, January}, 29, YearQuarter{2024, 1}},
+		4: {YearMonth{2023, February}, YearMonth{2023, March}, YearMonth{2023, January}, 28, YearQuarter{2023, 1}},
+		5: {YearMonth{0, January}, YearMonth{0, February}, YearMonth{-1, December}, 31, YearQuarter{0, 1}},
+	}
+
+	for i, tt := range tests {
+		if got := tt.ym.Next(); got != tt.next {
+			t.Errorf("#%d:: %v.Next() = %v, want %v", i, tt.ym, got, tt.next)
+		}
+		if got := tt.ym.Prev(); got != tt.prev {
+			t.Errorf("#%d:: %v.Prev() = %v, want %v", i, tt.ym, got, tt.prev)
+		}
+		if got := tt.ym.Days(); got != tt.days {
+			t.Errorf("#%d:: %v.Days() = %d, want %d", i, tt.ym, got, tt.days)
+		}
+		if got := tt.ym.Quarter(); got != tt.quarter {
+			t.Errorf("#%d:: %v.Quarter() = %v, want %v", i, tt.ym, got, tt.quarter)
+		}
+	}
+
+	if got := (YearMonth{2023, October}).AddMonths(-22); got != (YearMonth{2021, December}) {
+		t.Errorf("AddMonths(-22) = %v", got)
+	}
+}
+
+func TestYearQuarter(t *testing.T) {
+	tests := [...]struct {
+		yq   YearQuarter
+		next YearQuarter
+		prev YearQuarter
+		days int
+	}{
+		0: {YearQuarter{2023, 1}, YearQuarter{2023, 2}, YearQuarter{2022, 4}, 90},
+		1: {YearQuarter{2024, 1}, YearQuarter{2024, 2}, YearQuarter{2023, 4}, 91},
+		2: {YearQuarter{2023, 2}, YearQuarter{2023, 3}, YearQuarter{2023, 1}, 91},
+		3: {YearQuarter{2023, 3}, YearQuarter{2023, 4}, YearQuarter{2023, 2}, 92},
+		4: {YearQuarter{2023, 4}, YearQuarter{2024, 1}, YearQuarter{2023, 3}, 92},
+	}
+
+	for i, tt := range tests {
+		if got := tt.yq.Next(); got != tt.next {
+			t.Errorf("#%d:: %v.Next() = %v, want %v", i, tt.yq, got, tt.next)
+		}
+		if got := tt.yq.Prev(); got != tt.prev {
+			t.Errorf("#%d:: %v.Prev() = %v, want %v", i, tt.yq, got, tt.prev)
+		}
+		if got := tt.yq.Days(); got != tt.days {
+			t.Errorf("#%d:: %v.Days() = %d, want %d", i, tt.yq, got, tt.days)
+		}
+	}
+}
+
+func TestYearMonthStartEnd(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ym := YearMonth{2023, October}
+	start, end := ym.Start(la, LayoutTimestamp), ym.End(la, LayoutTimestamp)
+	if want := Date(2023, October, 1, 0, 0, 0, 0, la); !start.Equal(want) || start.GetLayout() != LayoutTimestamp {
+		t.Errorf("Start() = %v, want %v", start, want)
+	}
+	if want := Date(2023, October, 31, 23, 59, 59, 999999999, la); !end.Equal(want) || end.GetLayout() != LayoutTimestamp {
+		t.Errorf("End() = %v, want %v", end, want)
+	}
+
+	yq := YearQuarter{2023, 4}
+	if got, want := yq.Start(UTC), Date(2023, October, 1, 0, 0, 0, 0, UTC); !got.Equal(want) {
+		t.Errorf("%v.Start() = %v, want %v", yq, got, want)
+	}
+	if got, want := yq.End(UTC), Date(2023, December, 31, 23, 59, 59, 999999999, UTC); !got.Equal(want) {
+		t.Errorf("%v.End() = %v, want %v", yq, got, want)
+	}
+	if got := YearQuarterOf(Date(2023, November, 15, 0, 0, 0, 0, UTC)); got != yq {
+		t.Errorf("YearQuarterOf() = %v, want %v", got, yq)
+	}
+}
+
+func TestParseYearMonthQuarter(t *testing.T) {
+	monthTests := [...]struct {
+		input   string
+		want    YearMonth
+		wantErr bool
+	}{
+		0: {"2023-10", YearMonth{2023, October}, false},
+		1: {"0001-01", YearMonth{1, January}, false},
+		2: {"2023-13", YearMonth{}, true},
+		3: {"2023-1", YearMonth{}, true},
+		4: {"2023-Q4", YearMonth{}, true},
+	}
+	for i, tt := range monthTests {
+		got, err := ParseYearMonth(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("#%d:: ParseYearMonth(%q) = %v, %v", i, tt.input, got, err)
+		}
+		if err == nil && got.String() != tt.input {
+			t.Errorf("#%d:: String() = %q, want %q", i, got.String(), tt.input)
+		}
+	}
+
+	quarterTests := [...]struct {
+		input   string
+		want    YearQuarter
+		wantErr bool
+	}{
+		0: {"2023-Q4", YearQuarter{2023, 4}, false},
+		1: {"2024-Q1", YearQuarter{2024, 1}, false},
+		2: {"2023-Q5", YearQuarter{}, true},
+		3: {"2023-Q0", YearQuarter{}, true},
+		4: {"2023-q4", YearQuarter{}, true},
+		5: {"2023-10", YearQuarter{}, true},
+		6: {"23-Q4", YearQuarter{}, true},
+	}
+	for i, tt := range quarterTests {
+		got, err := ParseYearQuarter(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("#%d:: ParseYearQuarter(%q) = %v, %v", i, tt.input, got, err)
+		}
+		if err == nil && got.String() != tt.input {
+			t.Errorf("#%d:: String() = %q, want %q", i, got.String(), tt.input)
+		}
+	}
+}
+
+func TestYearMonthQuarterEncoding(t *testing.T) {
+	type report struct {
+		Month   YearMonth   `json:"month"`
+		Quarter YearQuarter `json:"quarter"`
+	}
+	r := report{YearMonth{2023, October}, YearQuarter{2023, 4}}
+	b, err := json.Marshal(r)
+	if want := `{"month":"2023-10","quarter":"2023-Q4"}`; err != nil || string(b) != want {
+		t.Errorf("json.Marshal() = %s, %v, want %s", b, err, want)
+	}
+	var got report
+	if err := json.Unmarshal(b, &got); err != nil || got != r {
+		t.Errorf("json.Unmarshal(%s) = %v, %v", b, got, err)
+	}
+
+	if v, err := r.Month.Value(); err != nil || v != "2023-10" {
+		t.Errorf("YearMonth.Value() = %v, %v", v, err)
+	}
+	if v, err := r.Quarter.Value(); err != nil || v != "2023-Q4" {
+		t.Errorf("YearQuarter.Value() = %v, %v", v, err)
+	}
+	if _, err := (YearQuarter{2023, 5}).Value(); err == nil {
+		t.Errorf("YearQuarter.Value() of an invalid quarter error = nil")
+	}
+
+	for _, src := range []interface{}{"2023-10", []byte("2023-10"), time.Date(2023, October, 16, 0, 0, 0, 0, UTC)} {
+		var ym YearMonth
+		if err := ym.Scan(src); err != nil || ym != r.Month {
+			t.Errorf("YearMonth.Scan(%#v) = %v, %v", src, ym, err)
+		}
+	}
+	for _, src := range []interface{}{"2023-Q4", []byte("2023-Q4"), time.Date(2023, October, 16, 0, 0, 0, 0, UTC)} {
+		var yq YearQuarter
+		if err := yq.Scan(src); err != nil || yq != r.Quarter {
+			t.Errorf("YearQuarter.Scan(%#v) = %v, %v", src, yq, err)
+		}
+	}
+
+	// The zero values are NULL.
+	if v, err := (YearMonth{}).Value(); err != nil || v != nil {
+		t.Errorf("YearMonth.Value() of the zero value = %v, %v, want nil", v, err)
+	} else if ym := r.Month; ym.Scan(v) != nil || ym != (YearMonth{}) {
+		t.Errorf("YearMonth.Scan(%#v) = %v", v, ym)
+	}
+	if v, err := (YearQuarter{}).Value(); err != nil || v != nil {
+		t.Errorf("YearQuarter.Value() of the zero value = %v, %v, want nil", v, err)
+	} else if yq := r.Quarter; yq.Scan(v) != nil || yq != (YearQuarter{}) {
+		t.Errorf("YearQuarter.Scan(%#v) = %v", v, yq)
+	}
+}
@@ -0,0 +1,92 @@
+package toki
+
+import (
+	"time"
+)
+
+// A Transition describes a change of the offset or abbreviation in use
+// in a Location.
+type Transition struct {
+	// When is the first instant at which the new zone is in effect.
+	When Toki
+
+	// Name, Offset and IsDST describe the zone in effect from When.
+	Name   string
+	Offset int
+	IsDST  bool
+
+	// PrevName, PrevOffset and PrevIsDST describe the zone in effect
+	// just before When.
+	PrevName   string
+	PrevOffset int
+	PrevIsDST  bool
+}
+
+// Transitions returns every transition of loc in the half-open range [from, to),
+// in chronological order.
+func Transitions(loc *Location, from, to Toki) []Transition {
+	var ts []Transition
+	t := from.Add(-time.Nanosecond)
+	for {
+		tr, ok := NextTransition(loc, t)
+		if !ok || !tr.When.Before(to) {
+			return ts
+		}
+		ts = append(ts, tr)
+		t = tr.When
+	}
+}
+
+// NextTransition returns the first transition of loc strictly after t.
+// ok is false if the zone in effect at t goes on forever.
+func NextTransition(loc *Location, t Toki) (tr Transition, ok bool) {
+	cur := t.Time.In(loc)
+	for {
+		_, end := cur.ZoneBounds()
+		if end.IsZero() {
+			return Transition{}, false
+		}
+		if tr, ok = transitionAt(t.GetLayout(), end); ok {
+			return tr, true
+		}
+		cur = end
+	}
+}
+
+// PrevTransition returns the last transition of loc strictly before t.
+// ok is false if the zone in effect just before t began at the beginning of time.
+func PrevTransition(loc *Location, t Toki) (tr Transition, ok bool) {
+	cur := t.Time.In(loc).Add(-time.Nanosecond)
+	for {
+		start, _ := cur.ZoneBounds()
+		if start.IsZero() {
+			return Transition{}, false
+		}
+		if tr, ok = transitionAt(t.GetLayout(), start); ok {
+			return tr, true
+		}
+		cur = start.Add(-time.Nanosecond)
+	}
+}
+
+// transitionAt reports the transition taking effect at bound. ok is false
+// if the zones on both sides of bound share the same name, offset and DST
+// flag, which happens when a tzdata entry changes only in metadata.
+func transitionAt(layout string, bound time.Time) (tr Transition, ok bool) {
+	before := bound.Add(-time.Nanosecond)
+	name, offset := bound.Zone()
+	prevName, prevOffset := before.Zone()
+	isDST, prevIsDST := bound.IsDST(), before.IsDST()
+	if name == prevName && offset == prevOffset && isDST == prevIsDST {
+		return Transition{}, false
+	}
+	return Transition{
+		When:       Toki{layout: layout, Time: bound},
+		Name:       name,
+		Offset:     offset,
+		IsDST:      isDST,
+		PrevName:   prevName,
+		PrevOffset: prevOffset,
+		PrevIsDST:  prevIsDST,
+	}, true
+}
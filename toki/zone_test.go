@@ -0,0 +1,95 @@
+package toki
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTransitions(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [...]struct {
+		loc      *Location
+		from, to Toki
+		want     []Transition
+	}{
+		0: {la, Date(2023, January, 1, 0, 0, 0, 0, UTC), Date(2024, January, 1, 0, 0, 0, 0, UTC), []Transition{
+			{When: Date(2023, March, 12, 10, 0, 0, 0, UTC), Name: "PDT", Offset: -7 * 60 * 60, IsDST: true, PrevName: "PST", PrevOffset: -8 * 60 * 60},
+			{When: Date(2023, November, 5, 9, 0, 0, 0, UTC), Name: "PST", Offset: -8 * 60 * 60, PrevName: "PDT", PrevOffset: -7 * 60 * 60, PrevIsDST: true},
+		}},
+		// from is inclusive, to is exclusive.
+		1: {la, Date(2023, March, 12, 10, 0, 0, 0, UTC), Date(2023, November, 5, 9, 0, 0, 0, UTC), []Transition{
+			{When: Date(2023, March, 12, 10, 0, 0, 0, UTC), Name: "PDT", Offset: -7 * 60 * 60, IsDST: true, PrevName: "PST", PrevOffset: -8 * 60 * 60},
+		}},
+		2: {shanghai, Date(1990, January, 1, 0, 0, 0, 0, UTC), Date(2000, January, 1, 0, 0, 0, 0, UTC), []Transition{
+			{When: Date(1990, April, 14, 18, 0, 0, 0, UTC), Name: "CDT", Offset: 9 * 60 * 60, IsDST: true, PrevName: "CST", PrevOffset: 8 * 60 * 60},
+			{When: Date(1990, September, 15, 17, 0, 0, 0, UTC), Name: "CST", Offset: 8 * 60 * 60, PrevName: "CDT", PrevOffset: 9 * 60 * 60, PrevIsDST: true},
+			{When: Date(1991, April, 13, 18, 0, 0, 0, UTC), Name: "CDT", Offset: 9 * 60 * 60, IsDST: true, PrevName: "CST", PrevOffset: 8 * 60 * 60},
+			{When: Date(1991, September, 14, 17, 0, 0, 0, UTC), Name: "CST", Offset: 8 * 60 * 60, PrevName: "CDT", PrevOffset: 9 * 60 * 60, PrevIsDST: true},
+		}},
+		3: {UTC, Date(1900, January, 1, 0, 0, 0, 0, UTC), Date(2100, January, 1, 0, 0, 0, 0, UTC), nil},
+		4: {FixedZone("JST", 9*60*60), Date(1900, January, 1, 0, 0, 0, 0, UTC), Date(2100, January, 1, 0, 0, 0, 0, UTC), nil},
+	}
+
+	for i, tt := range tests {
+		got := Transitions(tt.loc, tt.from, tt.to)
+		if len(got) != len(tt.want) {
+			t.Errorf("#%d:: Transitions(%v, %v, %v) returned %d transitions, want %d: %+v", i, tt.loc, tt.from, tt.to, len(got), len(tt.want), got)
+			continue
+		}
+		for j, tr := range got {
+			w := tt.want[j]
+			if !tr.When.Equal(w.When) || tr.Name != w.Name || tr.Offset != w.Offset || tr.IsDST != w.IsDST ||
+				tr.PrevName != w.PrevName || tr.PrevOffset != w.PrevOffset || tr.PrevIsDST != w.PrevIsDST {
+				t.Errorf("#%d:: transition %d = %+v, want %+v", i, j, tr, w)
+			}
+		}
+	}
+}
+
+func TestNextPrevTransition(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spring := Date(2023, March, 12, 10, 0, 0, 0, UTC)
+	fall := Date(2023, November, 5, 9, 0, 0, 0, UTC)
+
+	tests := [...]struct {
+		give       Toki
+		next, prev Toki
+	}{
+		0: {Date(2023, June, 1, 0, 0, 0, 0, UTC), fall, spring},
+		1: {spring, Date(2023, November, 5, 9, 0, 0, 0, UTC), Date(2022, November, 6, 9, 0, 0, 0, UTC)},
+		2: {spring.Add(-time.Nanosecond), spring, Date(2022, November, 6, 9, 0, 0, 0, UTC)},
+		3: {fall.Add(time.Nanosecond), Date(2024, March, 10, 10, 0, 0, 0, UTC), fall},
+		// Far beyond the last tzdata entry the POSIX rule is used.
+		4: {Date(2100, June, 1, 0, 0, 0, 0, UTC), Date(2100, November, 7, 9, 0, 0, 0, UTC), Date(2100, March, 14, 10, 0, 0, 0, UTC)},
+	}
+
+	for i, tt := range tests {
+		next, ok := NextTransition(la, tt.give)
+		if !ok || !next.When.Equal(tt.next) {
+			t.Errorf("#%d:: NextTransition(%v) = %v, %t, want %v", i, tt.give, next.When, ok, tt.next)
+		}
+		prev, ok := PrevTransition(la, tt.give)
+		if !ok || !prev.When.Equal(tt.prev) {
+			t.Errorf("#%d:: PrevTransition(%v) = %v, %t, want %v", i, tt.give, prev.When, ok, tt.prev)
+		}
+	}
+
+	if _, ok := NextTransition(UTC, Now()); ok {
+		t.Errorf("NextTransition(UTC) reported a transition")
+	}
+	if _, ok := PrevTransition(UTC, Now()); ok {
+		t.Errorf("PrevTransition(UTC) reported a transition")
+	}
+}